@@ -1,8 +1,11 @@
 package auth
 
-type AuthField string
+import "github.com/pip-services3-gox/pip-services3-rpc-gox/services"
 
-const PipAuthUser AuthField = "user"
-const PipAuthUserId AuthField = "user_id"
-const PipAuthAdmin AuthField = "admin"
-const PipAuthRoles AuthField = "roles"
+// AuthField is an alias of services.AuthField, so services can read the authenticated user.
+type AuthField = services.AuthField
+
+const PipAuthUser = services.PipAuthUser
+const PipAuthUserId = services.PipAuthUserId
+const PipAuthAdmin = services.PipAuthAdmin
+const PipAuthRoles = services.PipAuthRoles
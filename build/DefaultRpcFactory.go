@@ -11,6 +11,12 @@ import (
 //	see HttpEndpoint
 //	see HeartbeatRestService
 //	see StatusRestService
+//	see StaticRestService
+//	see HealthRestService
+//	see AboutRestService
+//	see AdminRestService
+//	see ProfilingRestService
+//	see SwaggerRestService
 type DefaultRpcFactory struct {
 	cbuild.Factory
 }
@@ -23,9 +29,21 @@ func NewDefaultRpcFactory() *DefaultRpcFactory {
 	httpEndpointDescriptor := cref.NewDescriptor("pip-services", "endpoint", "http", "*", "1.0")
 	statusServiceDescriptor := cref.NewDescriptor("pip-services", "status-service", "http", "*", "1.0")
 	heartbeatServiceDescriptor := cref.NewDescriptor("pip-services", "heartbeat-service", "http", "*", "1.0")
+	staticServiceDescriptor := cref.NewDescriptor("pip-services", "static-service", "http", "*", "1.0")
+	healthServiceDescriptor := cref.NewDescriptor("pip-services", "health-service", "http", "*", "1.0")
+	aboutServiceDescriptor := cref.NewDescriptor("pip-services", "about-service", "http", "*", "1.0")
+	adminServiceDescriptor := cref.NewDescriptor("pip-services", "admin-service", "http", "*", "1.0")
+	profilingServiceDescriptor := cref.NewDescriptor("pip-services", "profiling-service", "http", "*", "1.0")
+	swaggerServiceDescriptor := cref.NewDescriptor("pip-services", "swagger-service", "http", "*", "1.0")
 
 	c.RegisterType(httpEndpointDescriptor, services.NewHttpEndpoint)
 	c.RegisterType(heartbeatServiceDescriptor, services.NewHeartbeatRestService)
 	c.RegisterType(statusServiceDescriptor, services.NewStatusRestService)
+	c.RegisterType(staticServiceDescriptor, services.NewStaticRestService)
+	c.RegisterType(healthServiceDescriptor, services.NewHealthRestService)
+	c.RegisterType(aboutServiceDescriptor, services.NewAboutRestService)
+	c.RegisterType(adminServiceDescriptor, services.NewAdminRestService)
+	c.RegisterType(profilingServiceDescriptor, services.NewProfilingRestService)
+	c.RegisterType(swaggerServiceDescriptor, services.NewSwaggerRestService)
 	return &c
 }
@@ -1,11 +1,15 @@
 package clients
 
 import (
+	"encoding/json"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
 	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
 )
 
 // HandleHttpResponse method helps handle http response body
@@ -34,9 +38,99 @@ func HandleHttpResponse[T any](r *http.Response, correlationId string) (T, error
 				WithCause(err)
 		}
 
+		// Other formats than JSON are decoded by serializers registered for the response content type
+		serializer, ok := services.Serializers.Get(r.Header.Get("Content-Type"))
+		if ok && serializer.ContentType() != services.JsonContentType && len(buffer) > 0 {
+			var result T
+			if err = serializer.Deserialize(buffer, &result); err != nil {
+				return defaultValue, cerr.ApplicationErrorFactory.
+					Create(&cerr.ErrorDescription{
+						Type:          "Application",
+						Category:      "Application",
+						Status:        r.StatusCode,
+						Code:          "",
+						Message:       err.Error(),
+						CorrelationId: correlationId,
+					}).
+					WithCause(err)
+			}
+			return result, nil
+		}
+
 		return convert.NewDefaultCustomTypeJsonConvertor[T]().FromJson(string(buffer))
 
 	}
 
 	return defaultValue, nil
 }
+
+// HandleHttpStream method helps decode streamed http response body item by item
+// without buffering the whole result. It supports newline-delimited JSON and JSON arrays.
+// Decoding stops when the callback returns an error.
+//	Parameters:
+//		- r *http.Response a response returned by RestClient.CallStream
+//		- correlationId string (optional) transaction id to trace execution through call chain.
+//		- callback func(item T) error a function called for every decoded item
+//	Returns: err error a decoding error, a callback error or an error sent by the server
+func HandleHttpStream[T any](r *http.Response, correlationId string, callback func(item T) error) error {
+	if r == nil {
+		return nil
+	}
+	defer r.Body.Close()
+
+	decoder := json.NewDecoder(r.Body)
+	array := !strings.HasPrefix(r.Header.Get("Content-Type"), services.NdjsonContentType)
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return handleHttpStreamError(r, correlationId, err)
+		}
+	}
+
+	for !array || decoder.More() {
+		var item T
+		err := decoder.Decode(&item)
+		if err == io.EOF && !array {
+			break
+		}
+		if err != nil {
+			return handleHttpStreamError(r, correlationId, err)
+		}
+		if err = callback(item); err != nil {
+			return err
+		}
+	}
+
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return handleHttpStreamError(r, correlationId, err)
+		}
+	}
+
+	// Trailers are available after the body is read to the end
+	_, _ = io.Copy(io.Discard, r.Body)
+	return handleHttpStreamError(r, correlationId, nil)
+}
+
+func handleHttpStreamError(r *http.Response, correlationId string, err error) error {
+	// Read the rest of the body to receive trailers with the server error
+	_, _ = io.Copy(io.Discard, r.Body)
+	if trailer := r.Trailer.Get(services.StreamErrorTrailer); trailer != "" {
+		errDesc := cerr.ErrorDescription{}
+		if jsonErr := json.Unmarshal([]byte(trailer), &errDesc); jsonErr == nil {
+			return cerr.ApplicationErrorFactory.Create(&errDesc)
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	return cerr.ApplicationErrorFactory.
+		Create(&cerr.ErrorDescription{
+			Type:          "Application",
+			Category:      "Application",
+			Status:        r.StatusCode,
+			Code:          "STREAM_ERROR",
+			Message:       err.Error(),
+			CorrelationId: correlationId,
+		}).
+		WithCause(err)
+}
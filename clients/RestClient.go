@@ -1,19 +1,23 @@
 package clients
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"io/ioutil"
 	"math"
+	"mime"
 	"net/http"
 	neturl "net/url"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/gorilla/websocket"
 
 	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
 	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
 	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
 	crefer "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
@@ -39,6 +43,13 @@ import (
 //			- connect_timeout:        connection timeout in milliseconds (default: 10 sec)
 //			- timeout:               invocation timeout in milliseconds (default: 10 sec)
 //			- correlation_id 	 place for adding correalationId, query - in query string, headers - in headers, both - in query and headers (default: query)
+//			- request_max_size:      max size of received WebSocket messages in bytes (default: 1MB)
+//			- websocket_ping_interval: interval between WebSocket pings in milliseconds (default: 30000)
+//			- content_type:          media type of request and response bodies, i.e. "application/cbor" (default: "application/json")
+//			- idempotency_key:       send generated "Idempotency-Key" header with POST requests that can be retried (default: true)
+//
+//	W3C trace context stored in the call context by services.WithTraceContext (or by HttpEndpoint
+//	for incoming requests) is propagated in "traceparent" and "tracestate" headers.
 //
 //	References:
 //		- *:logger:*:*:1.0         (optional) ILogger components to pass log messages
@@ -104,13 +115,18 @@ type RestClient struct {
 	Uri string
 	// add correlation id to headers
 	passCorrelationId string
+	// The serializer of request and response bodies.
+	Serializer services.ISerializer
+	// Generate idempotency keys for POST requests that can be retried.
+	IdempotencyKey bool
 }
 
 const (
-	DefaultRequestMaxSize = 1024 * 1024
-	DefaultConnectTimeout = 10000
-	DefaultTimeout        = 10000
-	DefaultRetriesCount   = 3
+	DefaultRequestMaxSize   = 1024 * 1024
+	DefaultConnectTimeout   = 10000
+	DefaultTimeout          = 10000
+	DefaultRetriesCount     = 3
+	DefaultEventStreamRetry = 3000
 )
 
 // NewRestClient creates new instance of RestClient
@@ -129,6 +145,7 @@ func NewRestClient() *RestClient {
 		"options.retries", DefaultRetriesCount,
 		"options.debug", true,
 		"options.correlation_id", "query",
+		"options.content_type", services.JsonContentType,
 	)
 	rc.ConnectionResolver = *rpccon.NewHttpConnectionResolver()
 	rc.Logger = clog.NewCompositeLogger()
@@ -139,6 +156,8 @@ func NewRestClient() *RestClient {
 	rc.Headers = cdata.NewEmptyStringValueMap()
 	rc.ConnectTimeout = 10000
 	rc.passCorrelationId = "query"
+	rc.Serializer = services.Serializers.Default()
+	rc.IdempotencyKey = true
 	return &rc
 }
 
@@ -160,6 +179,10 @@ func (c *RestClient) Configure(ctx context.Context, config *cconf.ConfigParams)
 
 	c.passCorrelationId = config.GetAsStringWithDefault("options.correlation_id_place", c.passCorrelationId)
 	c.passCorrelationId = config.GetAsStringWithDefault("options.correlation_id", c.passCorrelationId)
+
+	contentType := config.GetAsStringWithDefault("options.content_type", c.Serializer.ContentType())
+	c.Serializer = services.Serializers.GetOrDefault(contentType)
+	c.IdempotencyKey = config.GetAsBooleanWithDefault("options.idempotency_key", c.IdempotencyKey)
 }
 
 // SetReferences to dependent components.
@@ -187,8 +210,10 @@ func (c *RestClient) Instrument(ctx context.Context, correlationId string, name
 	c.Counters.IncrementOne(ctx, name+".call_count")
 	counterTiming := c.Counters.BeginTiming(ctx, name+".call_time")
 	traceTiming := c.Tracer.BeginTrace(ctx, correlationId, name, "")
+	traceContext, _ := services.GetTraceContext(ctx)
 	return services.NewInstrumentTiming(correlationId, name, "call",
-		c.Logger, c.Counters, counterTiming, traceTiming)
+		c.Logger, c.Counters, counterTiming, traceTiming).
+		WithTraceContext(traceContext)
 }
 
 // InstrumentError method are dds instrumentation to error handling.
@@ -303,6 +328,90 @@ func (c *RestClient) AddFilterParams(params *cdata.StringValueMap, filter *cdata
 	return params
 }
 
+// AddTokenizedPagingParams method are adds tokenized (cursor) paging parameters (token, take, total)
+// to invocation parameter map.
+//
+//	Parameters:
+//		- params  *cdata.StringValueMap          invocation parameters.
+//		- paging  *cdata.TokenizedPagingParams (optional) tokenized paging parameters
+//	Returns: invocation parameters with added paging parameters.
+func (c *RestClient) AddTokenizedPagingParams(params *cdata.StringValueMap, paging *cdata.TokenizedPagingParams) *cdata.StringValueMap {
+	if params == nil {
+		params = cdata.NewEmptyStringValueMap()
+	}
+	if paging != nil {
+		params.Put("total", paging.Total)
+		if paging.Token != "" {
+			params.Put("token", paging.Token)
+		}
+		if paging.Take >= 0 {
+			params.Put("take", paging.Take)
+		}
+	}
+	return params
+}
+
+// AddSortParams method are adds sort parameters to invocation parameter map
+// as "sort" parameter, i.e. "sort=name,-created". Descending fields have "-" prefix.
+//
+//	Parameters:
+//		- params  *cdata.StringValueMap      invocation parameters.
+//		- sort    *cdata.SortParams       (optional) sort parameters
+//	Returns: invocation parameters with added sort parameters.
+func (c *RestClient) AddSortParams(params *cdata.StringValueMap, sort *cdata.SortParams) *cdata.StringValueMap {
+	if params == nil {
+		params = cdata.NewEmptyStringValueMap()
+	}
+	if sort != nil && len(*sort) > 0 {
+		fields := make([]string, 0, len(*sort))
+		for _, field := range *sort {
+			if field.Ascending {
+				fields = append(fields, field.Name)
+			} else {
+				fields = append(fields, "-"+field.Name)
+			}
+		}
+		params.Put(services.SortParamName, strings.Join(fields, ","))
+	}
+	return params
+}
+
+// AddProjectionParams method are adds projection parameters to invocation parameter map
+// as "fields" parameter, i.e. "fields=a,b.c".
+//
+//	Parameters:
+//		- params      *cdata.StringValueMap      invocation parameters.
+//		- projection  *cdata.ProjectionParams (optional) projection parameters
+//	Returns: invocation parameters with added projection parameters.
+func (c *RestClient) AddProjectionParams(params *cdata.StringValueMap, projection *cdata.ProjectionParams) *cdata.StringValueMap {
+	if params == nil {
+		params = cdata.NewEmptyStringValueMap()
+	}
+	if projection != nil && projection.Len() > 0 {
+		params.Put(services.ProjectionParamName, projection.String())
+	}
+	return params
+}
+
+// AddArrayParams method are adds a multi-valued parameter to query parameters sent by CallWithQuery.
+// Values are sent as repeated parameters (?id=1&id=2), which services don't split by commas,
+// so values containing commas are decoded as they are. A single value is decoded as a comma list.
+//
+//	Parameters:
+//		- query   neturl.Values           query parameters with multiple values.
+//		- name    string                  a parameter name.
+//		- values  []any                   parameter values.
+//	Returns: query parameters with added values.
+func (c *RestClient) AddArrayParams(query neturl.Values, name string, values ...any) neturl.Values {
+	if query == nil {
+		query = neturl.Values{}
+	}
+	for _, value := range values {
+		query.Add(name, cconv.StringConverter.ToString(value))
+	}
+	return query
+}
+
 // AddPagingParams method are adds paging parameters (skip, take, total) to invocation parameter map.
 // Parameters:
 //   - params        invocation parameters.
@@ -341,6 +450,55 @@ func (c *RestClient) AddPagingParams(params *cdata.StringValueMap, paging *cdata
 func (c *RestClient) Call(ctx context.Context, method string, route string, correlationId string,
 	params *cdata.StringValueMap, data any) (*http.Response, error) {
 
+	return c.call(ctx, c.Client, method, route, correlationId, params, nil, data, "")
+}
+
+// CallWithQuery method are calls a remote method via HTTP/REST protocol
+// with query parameters that have multiple values, i.e. added by AddArrayParams.
+//
+//	Parameters:
+//		- ctx context.Context
+//		- method 	string           HTTP method: "get", "head", "post", "put", "delete"
+//		- route   string          a command route. Base route will be added to this route
+//		- correlationId  string    (optional) transaction id to trace execution through call chain.
+//		- params  cdata.StringValueMap          (optional) query parameters.
+//		- query   neturl.Values          (optional) query parameters with multiple values.
+//		- data   any           (optional) body object.
+//	Returns: *http.Response, error a response or error.
+func (c *RestClient) CallWithQuery(ctx context.Context, method string, route string, correlationId string,
+	params *cdata.StringValueMap, query neturl.Values, data any) (*http.Response, error) {
+
+	return c.call(ctx, c.Client, method, route, correlationId, params, query, data, "")
+}
+
+// CallStream method calls a remote method that streams its result via HTTP/REST protocol.
+// It asks for newline-delimited JSON and doesn't apply the invocation timeout,
+// so large results can be received incrementally with HandleHttpStream.
+//
+//	Parameters:
+//		- ctx context.Context
+//		- method 	string           HTTP method: "get", "head", "post", "put", "delete"
+//		- route   string          a command route. Base route will be added to this route
+//		- correlationId  string    (optional) transaction id to trace execution through call chain.
+//		- params  cdata.StringValueMap          (optional) query parameters.
+//		- data   any           (optional) body object.
+//	Returns: *http.Response, error a response with the stream or error.
+func (c *RestClient) CallStream(ctx context.Context, method string, route string, correlationId string,
+	params *cdata.StringValueMap, data any) (*http.Response, error) {
+
+	if !c.IsOpen() {
+		return nil, cerr.NewError("Client is not open")
+	}
+
+	// Streams are long-living, so the invocation timeout is not applied
+	streamClient := &http.Client{Transport: c.Client.Transport}
+	return c.call(ctx, streamClient, method, route, correlationId, params, nil, data,
+		services.NdjsonContentType+", "+services.JsonContentType)
+}
+
+func (c *RestClient) call(ctx context.Context, client *http.Client, method string, route string,
+	correlationId string, params *cdata.StringValueMap, query neturl.Values, data any, accept string) (*http.Response, error) {
+
 	method = strings.ToUpper(method)
 
 	if params == nil {
@@ -351,27 +509,57 @@ func (c *RestClient) Call(ctx context.Context, method string, route string, corr
 		params = c.AddCorrelationId(params, correlationId)
 	}
 
-	url := c.buildURL(route, params)
+	url := c.buildURL(route, params, query)
 
 	if !c.IsOpen() {
 		return nil, cerr.NewError("Client is not open")
 	}
 
-	var jsonStr string
+	var body []byte
 	if data != nil {
-		jsonStr, _ = convert.JsonConverter.ToJson(data)
+		var err error
+		body, err = c.Serializer.Serialize(data)
+		if err != nil {
+			return nil, cerr.NewBadRequestError(
+				correlationId,
+				"SERIALIZATION_FAILED",
+				"Failed to serialize request body",
+			).
+				WithCause(err)
+		}
+	}
+
+	stream := accept != ""
+	if !stream {
+		accept = c.Serializer.ContentType()
 	}
 
 	retries := c.Retries
 	var response *http.Response
 
+	// All attempts of a POST share one key, so the service doesn't process repeats twice
+	idempotencyKey := ""
+	if c.IdempotencyKey && method == http.MethodPost && retries > 1 &&
+		c.Headers.GetAsString(services.IdempotencyKeyHeader) == "" {
+		idempotencyKey = cdata.IdGenerator.NextLong()
+	}
+
 	for retries > 0 {
-		req, err := c.prepareRequest(ctx, correlationId, method, url, []byte(jsonStr))
+		req, err := c.prepareRequest(ctx, correlationId, method, url, body)
 		if err != nil {
 			return nil, err
 		}
 
-		response, err = c.Client.Do(req)
+		if stream {
+			// Bind the stream to the context so callers can cancel reading
+			req = req.WithContext(ctx)
+		}
+		req.Header.Set("Accept", accept)
+		if idempotencyKey != "" {
+			req.Header.Set(services.IdempotencyKeyHeader, idempotencyKey)
+		}
+
+		response, err = client.Do(req)
 		if err != nil {
 			retries--
 			if retries == 0 {
@@ -405,6 +593,248 @@ func (c *RestClient) Call(ctx context.Context, method string, route string, corr
 	return response, nil
 }
 
+// CallEventStream method are subscribes to a remote Server-Sent Events stream via HTTP GET.
+// Received events are sent to the returned channel. When the connection is lost
+// the client reconnects after the retry time set by the server (3 seconds by default)
+// and resumes the stream by sending the id of the last received event in "Last-Event-ID" header.
+// The channel is closed when the context is cancelled or the server responds with 204 status code.
+// Reconnection stops and the channel is closed when the server rejects the stream with a client error
+// status, i.e. 401, 403 or 404, as retries can't succeed. The error is logged, and it is returned
+// when the first connection is rejected.
+//
+//	Parameters:
+//		- ctx context.Context	a context to cancel the subscription
+//		- route   string          a command route. Base route will be added to this route
+//		- correlationId  string    (optional) transaction id to trace execution through call chain.
+//		- params  cdata.StringValueMap          (optional) query parameters.
+//	Returns: <-chan *services.ServerSentEvent, error a channel with events or an error if the first connection failed.
+func (c *RestClient) CallEventStream(ctx context.Context, route string, correlationId string,
+	params *cdata.StringValueMap) (<-chan *services.ServerSentEvent, error) {
+
+	if params == nil {
+		params = cdata.NewEmptyStringValueMap()
+	}
+
+	if c.passCorrelationId == "query" || c.passCorrelationId == "both" {
+		params = c.AddCorrelationId(params, correlationId)
+	}
+
+	url := c.buildURL(route, params, nil)
+
+	if !c.IsOpen() {
+		return nil, cerr.NewError("Client is not open")
+	}
+
+	// Streams are long-living, so the invocation timeout is not applied
+	streamClient := &http.Client{Transport: c.Client.Transport}
+
+	response, err := c.openEventStream(ctx, streamClient, correlationId, url, "")
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *services.ServerSentEvent)
+	go func() {
+		defer close(events)
+
+		lastEventId := ""
+		retry := DefaultEventStreamRetry
+		for response != nil {
+			lastEventId, retry = c.readEventStream(ctx, response, events, lastEventId, retry)
+			response = nil
+
+			for response == nil {
+				select {
+				case <-time.After(time.Duration(retry) * time.Millisecond):
+				case <-ctx.Done():
+					return
+				}
+
+				response, err = c.openEventStream(ctx, streamClient, correlationId, url, lastEventId)
+				if err != nil {
+					if !isRetryableError(err) {
+						c.Logger.Error(ctx, correlationId, err, "Event stream at %s was rejected", url)
+						return
+					}
+					c.Logger.Warn(ctx, correlationId, "Failed to reconnect to event stream at %s: %s", url, err.Error())
+					continue
+				}
+				if response == nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// isRetryableError checks if a request can succeed when it is repeated.
+// Client errors can't be fixed by retries, except request timeouts and rate limits.
+func isRetryableError(err error) bool {
+	appErr, ok := err.(*cerr.ApplicationError)
+	if !ok || appErr.Status < 400 || appErr.Status >= 500 {
+		return true
+	}
+	return appErr.Status == http.StatusRequestTimeout || appErr.Status == http.StatusTooEarly ||
+		appErr.Status == http.StatusTooManyRequests
+}
+
+func (c *RestClient) openEventStream(ctx context.Context, client *http.Client, correlationId string,
+	url string, lastEventId string) (*http.Response, error) {
+
+	req, err := c.prepareRequest(ctx, correlationId, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", services.EventStreamContentType)
+	if lastEventId != "" {
+		req.Header.Set(services.LastEventIdHeader, lastEventId)
+	}
+
+	response, err := client.Do(req)
+	if err != nil {
+		return nil, cerr.NewUnknownError(
+			correlationId,
+			"COMMUNICATION_ERROR",
+			"Unknown communication problem on REST client",
+		).WithCause(err)
+	}
+
+	if response.StatusCode == 204 {
+		_ = response.Body.Close()
+		return nil, nil
+	}
+
+	if response.StatusCode >= 400 {
+		defer response.Body.Close()
+		return nil, c.handleResponseError(response, correlationId)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) readEventStream(ctx context.Context, response *http.Response,
+	events chan<- *services.ServerSentEvent, lastEventId string, retry int) (string, int) {
+
+	defer response.Body.Close()
+
+	reader := bufio.NewReader(response.Body)
+	event := &services.ServerSentEvent{}
+	data := make([]string, 0)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return lastEventId, retry
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		// Dispatch the event on empty line
+		if line == "" {
+			if len(data) > 0 {
+				event.Id = lastEventId
+				event.Data = strings.Join(data, "\n")
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return lastEventId, retry
+				}
+			}
+			event = &services.ServerSentEvent{}
+			data = make([]string, 0)
+			continue
+		}
+
+		// Skip comments and heartbeats
+		if line[0] == ':' {
+			continue
+		}
+
+		field, value := line, ""
+		if index := strings.IndexByte(line, ':'); index >= 0 {
+			field = line[:index]
+			value = strings.TrimPrefix(line[index+1:], " ")
+		}
+
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				lastEventId = value
+			}
+		case "retry":
+			if value, err := strconv.Atoi(value); err == nil {
+				retry = value
+				event.Retry = value
+			}
+		}
+	}
+}
+
+// ConnectWebSocket method are opens a WebSocket connection to a remote route.
+// Default headers, correlation id and trace context are sent with the handshake request.
+//
+//	Parameters:
+//		- ctx context.Context
+//		- route   string          a command route. Base route will be added to this route
+//		- correlationId  string    (optional) transaction id to trace execution through call chain.
+//		- params  cdata.StringValueMap          (optional) query parameters.
+//	Returns: *services.WebSocketConnection, error an opened connection or error.
+func (c *RestClient) ConnectWebSocket(ctx context.Context, route string, correlationId string,
+	params *cdata.StringValueMap) (*services.WebSocketConnection, error) {
+
+	if params == nil {
+		params = cdata.NewEmptyStringValueMap()
+	}
+
+	if c.passCorrelationId == "query" || c.passCorrelationId == "both" {
+		params = c.AddCorrelationId(params, correlationId)
+	}
+
+	url := c.buildURL(route, params, nil)
+	if strings.HasPrefix(url, "https://") {
+		url = "wss://" + strings.TrimPrefix(url, "https://")
+	} else {
+		url = "ws://" + strings.TrimPrefix(url, "http://")
+	}
+
+	if !c.IsOpen() {
+		return nil, cerr.NewError("Client is not open")
+	}
+
+	req, err := c.prepareRequest(ctx, correlationId, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Del("Content-Type")
+
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: time.Duration(c.ConnectTimeout) * time.Millisecond,
+	}
+	conn, response, err := dialer.DialContext(ctx, url, req.Header)
+	if err != nil {
+		if response != nil && response.StatusCode >= 400 {
+			return nil, c.handleResponseError(response, correlationId)
+		}
+		return nil, cerr.NewConnectionError(
+			correlationId,
+			"CANNOT_CONNECT",
+			"Connection to WebSocket failed",
+		).WithDetails("url", url).WithCause(err)
+	}
+
+	maxMessageSize := c.Options.GetAsLongWithDefault("request_max_size", DefaultRequestMaxSize)
+	pingInterval := time.Duration(c.Options.GetAsLongWithDefault("websocket_ping_interval",
+		int64(services.DefaultWebSocketPingInterval/time.Millisecond))) * time.Millisecond
+	return services.NewWebSocketConnection(conn, maxMessageSize, pingInterval), nil
+}
+
 func (c *RestClient) waitForRetry(ctx context.Context, correlationId string, retries int) error {
 	waitTime := c.Timeout * int(math.Pow(float64(c.Retries-retries), 2))
 
@@ -438,10 +868,16 @@ func (c *RestClient) prepareRequest(ctx context.Context, correlationId string,
 			WithCause(err)
 	}
 	// Set headers
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", c.Serializer.ContentType())
 	if c.passCorrelationId == "headers" || c.passCorrelationId == "both" {
 		req.Header.Set("correlation_id", correlationId)
 	}
+	// Propagate W3C trace context as a child span of the current one
+	if traceContext, ok := services.GetTraceContext(ctx); ok {
+		traceContext.NewChild().Inject(req.Header)
+	} else {
+		services.NewTraceContext().Inject(req.Header)
+	}
 	for k, v := range c.Headers.Value() {
 		req.Header.Set(k, v)
 	}
@@ -463,11 +899,28 @@ func (c *RestClient) handleResponseError(response *http.Response, correlationId
 		return cerr.ApplicationErrorFactory.Create(&eDesct).WithCause(rErr)
 	}
 
+	// RFC 7807 problem details are converted back to application errors
+	if mediaType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type")); mediaType == services.ProblemJsonContentType {
+		problem := services.ProblemDetails{}
+		if err := json.Unmarshal(r, &problem); err == nil {
+			desc := problem.ToErrorDescription()
+			desc.Status = response.StatusCode
+			if desc.CorrelationId == "" {
+				desc.CorrelationId = correlationId
+			}
+			appErr := cerr.ApplicationErrorFactory.Create(desc)
+			appErr.Status = response.StatusCode
+			return appErr
+		}
+	}
+
+	// Errors are decoded as JSON unless they are sent in another registered format
+	serializer := services.Serializers.GetOrDefault(response.Header.Get("Content-Type"))
 	appErr := cerr.ApplicationError{}
-	_ = json.Unmarshal(r, &appErr)
+	_ = serializer.Deserialize(r, &appErr)
 	if appErr.Status == 0 && len(r) > 0 { // not standart Pip.Services error
 		values := make(map[string]any)
-		decodeErr := json.Unmarshal(r, &values)
+		decodeErr := serializer.Deserialize(r, &values)
 		if decodeErr != nil { // not json response
 			appErr.Message = (string)(r)
 		}
@@ -477,9 +930,9 @@ func (c *RestClient) handleResponseError(response *http.Response, correlationId
 	return &appErr
 }
 
-func (c *RestClient) buildURL(route string, params *cdata.StringValueMap) string {
+func (c *RestClient) buildURL(route string, params *cdata.StringValueMap, query neturl.Values) string {
 	route = c.createRequestRoute(route)
-	route = c.putParamsToRequestRoute(route, params)
+	route = c.putParamsToRequestRoute(route, params, query)
 	return c.Uri + route
 }
 
@@ -501,8 +954,8 @@ func (c *RestClient) createRequestRoute(route string) string {
 	return builder
 }
 
-func (c *RestClient) putParamsToRequestRoute(route string, params *cdata.StringValueMap) string {
-	if params.Len() > 0 {
+func (c *RestClient) putParamsToRequestRoute(route string, params *cdata.StringValueMap, query neturl.Values) string {
+	if params.Len() > 0 || len(query) > 0 {
 		var builder strings.Builder
 		builder.Grow(1024)
 		builder.WriteString(route)
@@ -513,6 +966,15 @@ func (c *RestClient) putParamsToRequestRoute(route string, params *cdata.StringV
 			builder.WriteString(neturl.QueryEscape(v))
 			builder.WriteString("&")
 		}
+		// Values of multi-valued parameters are sent as repeated parameters
+		for k, items := range query {
+			for _, item := range items {
+				builder.WriteString(neturl.QueryEscape(k))
+				builder.WriteString("=")
+				builder.WriteString(neturl.QueryEscape(item))
+				builder.WriteString("&")
+			}
+		}
 		route = builder.String()
 		if strings.HasSuffix(route, "&") {
 			route = strings.TrimRight(route, "&")
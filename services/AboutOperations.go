@@ -2,27 +2,36 @@ package services
 
 import (
 	"context"
-	"encoding/json"
-	"io"
+	"crypto/tls"
 	"net"
 	"net/http"
+	"strconv"
 	"time"
 
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
 	crefer "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
 	cinfo "github.com/pip-services3-gox/pip-services3-components-gox/info"
 )
 
+// AboutOperations helper class for about service.
+// It reports information about the server: context info, build, network addresses and TLS,
+// and about the calling client: address, browser, platform and authenticated user.
 type AboutOperations struct {
 	*RestOperations
 	contextInfo *cinfo.ContextInfo
 }
 
+// NewAboutOperations creates new instance of AboutOperations
 func NewAboutOperations() *AboutOperations {
 	return &AboutOperations{
 		RestOperations: NewRestOperations(),
 	}
 }
 
+// SetReferences  sets references to dependent components.
+//	Parameters:
+//		- ctx context.Context
+//		- references crefer.IReferences references to locate the component dependencies.
 func (c *AboutOperations) SetReferences(ctx context.Context, references crefer.IReferences) {
 	c.RestOperations.SetReferences(ctx, references)
 
@@ -35,51 +44,81 @@ func (c *AboutOperations) SetReferences(ctx context.Context, references crefer.I
 
 }
 
+// GetAboutOperation return function for get about information
 func (c *AboutOperations) GetAboutOperation() func(res http.ResponseWriter, req *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 		c.About(res, req)
 	}
 }
 
+// GetNetworkAddresses returns global unicast IPv4 and IPv6 addresses of the host network interfaces.
+//	Returns: []string IP addresses, IPv4 addresses go first
 func (c *AboutOperations) GetNetworkAddresses() []string {
 	interfacesAddrs, _ := net.InterfaceAddrs()
-	var addresses []string
+	ipv4 := make([]string, 0)
+	ipv6 := make([]string, 0)
 	for _, address := range interfacesAddrs {
-		ipAddr, ipOk := address.(*net.IPAddr)
-		if !ipOk {
+		var ip net.IP
+		switch addr := address.(type) {
+		case *net.IPNet:
+			ip = addr.IP
+		case *net.IPAddr:
+			ip = addr.IP
+		default:
 			continue
 		}
-		if ipAddr.IP.IsGlobalUnicast() && ipAddr.Network() == "ip4" {
-			addresses = append(addresses, address.String())
+		if !ip.IsGlobalUnicast() {
+			continue
+		}
+		if ip.To4() != nil {
+			ipv4 = append(ipv4, ip.String())
+		} else {
+			ipv6 = append(ipv6, ip.String())
 		}
-
 	}
-	return addresses
+	return append(ipv4, ipv6...)
 }
 
+// About method handles about requests
+//	Parameters:
+//		- req *http.Request  an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
 func (c *AboutOperations) About(res http.ResponseWriter, req *http.Request) {
 	about := make(map[string]any, 0)
 	server := make(map[string]any)
 
 	server["name"] = "unknown"
 	server["description"] = ""
-	server["properties"] = ""
-	server["uptime"] = ""
+	server["properties"] = make(map[string]string)
+	server["uptime"] = 0
 	server["start_time"] = ""
 	if c.contextInfo != nil {
 		server["name"] = c.contextInfo.Name
 		server["description"] = c.contextInfo.Description
 		server["properties"] = c.contextInfo.Properties
 		server["uptime"] = c.contextInfo.Uptime()
-		server["start_time"] = c.contextInfo.StartTime
+		server["start_time"] = c.contextInfo.StartTime.Format(time.RFC3339)
 	}
 
+	protocol := "http"
+	if req.TLS != nil {
+		protocol = "https"
+	}
+	host := HttpRequestDetector.DetectServerHost(req)
+	port := HttpRequestDetector.DetectServerPort(req)
+
 	server["current_time"] = time.Now().Format(time.RFC3339)
-	server["protocol"] = req.URL.Scheme
-	server["host"] = HttpRequestDetector.DetectServerHost(req)
+	server["protocol"] = protocol
+	server["host"] = host
 	server["addresses"] = c.GetNetworkAddresses()
-	server["port"] = HttpRequestDetector.DetectServerPort(req)
-	server["url"] = req.URL.String()
+	server["port"] = port
+	server["url"] = protocol + "://" + req.Host + req.URL.RequestURI()
+	if build := newBuildStatus(); build != nil {
+		server["build"] = build
+	}
+	if req.TLS != nil {
+		server["tls"] = tlsDetails(req.TLS)
+	}
 
 	about["server"] = server
 
@@ -87,13 +126,74 @@ func (c *AboutOperations) About(res http.ResponseWriter, req *http.Request) {
 	client["address"] = HttpRequestDetector.DetectAddress(req)
 	client["client"] = HttpRequestDetector.DetectBrowser(req)
 	client["platform"] = HttpRequestDetector.DetectPlatform(req)
-	client["user"] = req.URL.User
+	client["user"] = authenticatedUser(req)
+	if req.TLS != nil && len(req.TLS.PeerCertificates) > 0 {
+		client["certificate"] = req.TLS.PeerCertificates[0].Subject.String()
+	}
 
 	about["client"] = client
 
-	jsonObj, jsonErr := json.Marshal(about)
-	if jsonErr == nil {
-		_, _ = io.WriteString(res, (string)(jsonObj))
+	c.SendResult(res, req, about, nil)
+}
+
+// authenticatedUser gets id, name and roles of the user set by authentication interceptors.
+func authenticatedUser(req *http.Request) map[string]any {
+	var user *cdata.AnyValueMap
+	switch value := req.Context().Value(PipAuthUser).(type) {
+	case cdata.AnyValueMap:
+		user = &value
+	case *cdata.AnyValueMap:
+		user = value
+	}
+
+	userId, _ := req.Context().Value(PipAuthUserId).(string)
+	if user == nil && userId == "" {
+		return nil
+	}
+
+	result := make(map[string]any)
+	if user != nil {
+		if userId == "" {
+			userId = user.GetAsString(string(PipAuthUserId))
+		}
+		if userId == "" {
+			userId = user.GetAsString("id")
+		}
+		if name := user.GetAsString("name"); name != "" {
+			result["name"] = name
+		}
+		if login := user.GetAsString("login"); login != "" {
+			result["login"] = login
+		}
+		if roles := user.GetAsArray(string(PipAuthRoles)); roles.Len() > 0 {
+			result["roles"] = roles.Value()
+		}
+	}
+	result["id"] = userId
+	return result
+}
+
+// tlsDetails describes the negotiated TLS connection.
+func tlsDetails(state *tls.ConnectionState) map[string]any {
+	details := make(map[string]any)
+	details["version"] = tlsVersionName(state.Version)
+	details["cipher_suite"] = tls.CipherSuiteName(state.CipherSuite)
+	details["server_name"] = state.ServerName
+	details["negotiated_protocol"] = state.NegotiatedProtocol
+	details["resumed"] = state.DidResume
+	return details
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
 	}
-	//res.json(about)
+	return "0x" + strconv.FormatUint(uint64(version), 16)
 }
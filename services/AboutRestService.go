@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	crefer "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+)
+
+// AboutRestService service returns information about the server and the calling client via HTTP/REST protocol.
+// The service responds on /about route (can be changed) with a JSON object
+// that contains context info, build info, network addresses and TLS details of the server,
+// and address, browser, platform and authenticated user of the client.
+//
+//	Configuration parameters:
+//		- baseroute:           base route for remote URI (default: "")
+//		- route:               route to about operation (default: "about")
+//		- dependencies:
+//			- endpoint:        override for HTTP Endpoint dependency
+//		- connection(s):
+//			- discovery_key:   (optional) a key to retrieve the connection from IDiscovery
+//			- protocol:        connection protocol: http or https
+//			- host:            host name or IP address
+//			- port:            port number
+//			- uri:             resource URI or connection string with all parameters in it
+//
+//	References:
+//		- *:logger:*:*:1.0       (optional)  ILogger components to pass log messages
+//		- *:counters:*:*:1.0     (optional)  ICounters components to pass collected measurements
+//		- *:discovery:*:*:1.0    (optional)  IDiscovery services to resolve connection
+//		- *:endpoint:http:*:1.0  (optional) HttpEndpoint reference
+//		- *:context-info:*:*:1.0 (optional) ContextInfo to describe the server
+//
+//	see RestService
+//	see AboutOperations
+//
+//	Example:
+//		service := NewAboutRestService();
+//		service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+//			"connection.protocol", "http",
+//			"connection.host", "localhost",
+//			"connection.port", 8080,
+//		));
+//
+//		opnErr := service.Open(context.Background(), "123")
+//		if opnErr == nil {
+//			fmt.Println("The About service is accessible at http://+:8080/about");
+//		}
+type AboutRestService struct {
+	*RestService
+	operations *AboutOperations
+	route      string
+}
+
+// NewAboutRestService creates a new instance of c service.
+func NewAboutRestService() *AboutRestService {
+	c := &AboutRestService{}
+	c.RestService = InheritRestService(c)
+	c.operations = NewAboutOperations()
+	c.route = "about"
+	return c
+}
+
+// Configure component by passing configuration parameters.
+//	Parameters:
+//		- ctx context.Context
+//		- config configuration parameters to be set.
+func (c *AboutRestService) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.RestService.Configure(ctx, config)
+	c.route = config.GetAsStringWithDefault("route", c.route)
+}
+
+// SetReferences sets references to dependent components.
+//	Parameters:
+//		- ctx context.Context
+//		- references crefer.IReferences	references to locate the component dependencies.
+func (c *AboutRestService) SetReferences(ctx context.Context, references crefer.IReferences) {
+	c.RestService.SetReferences(ctx, references)
+	c.operations.SetReferences(ctx, references)
+}
+
+// Register all service routes in HTTP endpoint.
+func (c *AboutRestService) Register() {
+	c.RegisterRoute(http.MethodGet, c.route, nil, c.operations.GetAboutOperation())
+}
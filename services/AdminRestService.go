@@ -0,0 +1,384 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	crefer "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	cvalid "github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+)
+
+// RedactedValue replaces secret values in configurations returned by AdminRestService.
+const RedactedValue = "***"
+
+// AdminRestService is a service to administer a running microservice via HTTP/REST protocol.
+// It allows to change log levels without restart, inspect the effective configuration
+// and references, and to turn maintenance mode of HTTP endpoints on and off.
+//
+//	The service responds on the following routes:
+//		- GET  /admin/log_level     levels of the composite logger and referenced loggers
+//		- PUT  /admin/log_level     sets the level of all loggers, body: { "level": "debug" }
+//		- GET  /admin/config        configurations of referenced components with secrets redacted
+//		- GET  /admin/references    referenced components with their types and open states
+//		- GET  /admin/maintenance   maintenance mode of the endpoint: { "enabled": false }
+//		- PUT  /admin/maintenance   turns maintenance mode on or off, body: { "enabled": true }
+//
+//	All routes require a signed in user with "admin" role set by authentication interceptors.
+//	The check can be replaced with auth managers using SetAuthorize.
+//	Admin routes are served while maintenance mode is enabled. Health routes of HealthRestService
+//	are served as well, readiness reports "down" during maintenance.
+//
+//	Configuration parameters:
+//		- baseroute:              base route for remote URI
+//		- route:                  admin route (default: "admin")
+//		- dependencies:
+//			- endpoint:           override for HTTP Endpoint dependency
+//		- connection(s):
+//			- discovery_key:      (optional) a key to retrieve the connection from IDiscovery
+//			- protocol:           connection protocol: http or https
+//			- host:               host name or IP address
+//			- port:               port number
+//			- uri:                resource URI or connection string with all parameters in it
+//
+//	References:
+//		- *:logger:*:*:1.0       (optional) ILogger components to pass log messages and change levels
+//		- *:counters:*:*:1.0     (optional) ICounters components to pass collected measurements
+//		- *:discovery:*:*:1.0    (optional) IDiscovery services to resolve connection
+//		- *:endpoint:http:*:1.0  (optional) HttpEndpoint reference
+//		- *:*:*:*:*              (optional) components to report configurations and states
+//
+//	see: RestService
+//	see: auth.RoleAuthManager
+//
+//	Example:
+//		service := NewAdminRestService()
+//		service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+//			"connection.protocol", "http",
+//			"connection.host", "localhost",
+//			"connection.port", 8080,
+//		))
+//		service.SetAuthorize((&auth.RoleAuthManager{}).UserInRole("operator"))
+//
+//		opnErr := service.Open(context.Background(), "123")
+//		if opnErr == nil {
+//			fmt.Println("The Admin service is accessible at http://localhost:8080/admin")
+//		}
+type AdminRestService struct {
+	*RestService
+	references2 crefer.IReferences
+	route       string
+	authorize   func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc)
+}
+
+// configurable is implemented by components that expose their effective configuration.
+type configurable interface {
+	GetConfig() *cconf.ConfigParams
+}
+
+// NewAdminRestService method are creates a new instance of this service.
+func NewAdminRestService() *AdminRestService {
+	c := &AdminRestService{}
+	c.RestService = InheritRestService(c)
+	c.route = "admin"
+	c.authorize = adminAuthorize
+	return c
+}
+
+// Configure method are configures component by passing configuration parameters.
+//	Parameters:
+//		- ctx context.Context
+//		- config  *cconf.ConfigParams  configuration parameters to be set.
+func (c *AdminRestService) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.RestService.Configure(ctx, config)
+	c.route = config.GetAsStringWithDefault("route", c.route)
+}
+
+// SetReferences method are sets references to dependent components.
+//	Parameters:
+//		- ctx context.Context
+//		- references crefer.IReferences	references to locate the component dependencies.
+func (c *AdminRestService) SetReferences(ctx context.Context, references crefer.IReferences) {
+	c.references2 = references
+	c.RestService.SetReferences(ctx, references)
+}
+
+// SetAuthorize method are replaces the default admin role check, i.e. with an auth manager interceptor.
+// It must be called before the service is opened.
+//	Parameters:
+//		- authorize  an authorization interceptor, nil to allow all requests
+func (c *AdminRestService) SetAuthorize(authorize func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc)) {
+	c.authorize = authorize
+}
+
+// Register method are registers all service routes in HTTP endpoint.
+func (c *AdminRestService) Register() {
+	c.Endpoint.AddMaintenanceExemption(c.appendBaseRoute(c.route))
+
+	c.RegisterRouteWithAuth(http.MethodGet, c.route+"/log_level", nil, c.authorize, c.getLogLevel)
+	c.RegisterRouteWithAuth(http.MethodPut, c.route+"/log_level",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("body", cvalid.NewObjectSchema().
+				WithRequiredProperty("level", cconv.String)).Schema,
+		c.authorize, c.setLogLevel)
+	c.RegisterRouteWithAuth(http.MethodGet, c.route+"/config", nil, c.authorize, c.getConfig)
+	c.RegisterRouteWithAuth(http.MethodGet, c.route+"/references", nil, c.authorize, c.getReferences)
+	c.RegisterRouteWithAuth(http.MethodGet, c.route+"/maintenance", nil, c.authorize, c.getMaintenance)
+	c.RegisterRouteWithAuth(http.MethodPut, c.route+"/maintenance",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("body", cvalid.NewObjectSchema().
+				WithRequiredProperty("enabled", cconv.Boolean)).Schema,
+		c.authorize, c.setMaintenance)
+}
+
+// Handles requests for log levels
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *AdminRestService) getLogLevel(res http.ResponseWriter, req *http.Request) {
+	c.SendResult(res, req, c.logLevels(), nil)
+}
+
+// Handles requests to change log levels
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *AdminRestService) setLogLevel(res http.ResponseWriter, req *http.Request) {
+	correlationId := c.GetCorrelationId(req)
+
+	var body struct {
+		Level string `json:"level"`
+	}
+	if err := c.DecodeBody(req, &body); err != nil {
+		c.SendError(res, req, cerr.NewBadRequestError(correlationId, "INVALID_BODY",
+			"Failed to decode request body").WithCause(err))
+		return
+	}
+
+	level, ok := parseLogLevel(body.Level)
+	if !ok {
+		c.SendError(res, req, cerr.NewBadRequestError(correlationId, "INVALID_LOG_LEVEL",
+			"Log level "+body.Level+" is not supported").WithDetails("level", body.Level))
+		return
+	}
+
+	c.Logger.SetLevel(level)
+	for _, logger := range c.referencedLoggers() {
+		logger.SetLevel(level)
+	}
+	c.Logger.Info(req.Context(), correlationId, "Log level was changed to %s", clog.LevelConverter.ToString(level))
+
+	c.SendResult(res, req, c.logLevels(), nil)
+}
+
+// Handles requests for configurations
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *AdminRestService) getConfig(res http.ResponseWriter, req *http.Request) {
+	configs := make(map[string]map[string]string)
+	if c.references2 != nil {
+		for _, reference := range findReferencedComponents(c.references2) {
+			if component, ok := reference.component.(configurable); ok {
+				if config := component.GetConfig(); config != nil {
+					configs[reference.name] = RedactConfig(config).Value()
+				}
+			}
+		}
+	}
+	if c.localEndpoint && c.Endpoint != nil && c.Endpoint.GetConfig() != nil {
+		configs["endpoint"] = RedactConfig(c.Endpoint.GetConfig()).Value()
+	}
+	c.SendResult(res, req, configs, nil)
+}
+
+// Handles requests for references
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *AdminRestService) getReferences(res http.ResponseWriter, req *http.Request) {
+	states := make([]*ComponentStatus, 0)
+	if c.references2 != nil {
+		states = newComponentStates(c.references2)
+	}
+	c.SendResult(res, req, states, nil)
+}
+
+// Handles requests for maintenance mode
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *AdminRestService) getMaintenance(res http.ResponseWriter, req *http.Request) {
+	c.SendResult(res, req, map[string]bool{"enabled": c.Endpoint.IsMaintenanceEnabled()}, nil)
+}
+
+// Handles requests to turn maintenance mode on or off
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *AdminRestService) setMaintenance(res http.ResponseWriter, req *http.Request) {
+	correlationId := c.GetCorrelationId(req)
+
+	var body struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := c.DecodeBody(req, &body); err != nil {
+		c.SendError(res, req, cerr.NewBadRequestError(correlationId, "INVALID_BODY",
+			"Failed to decode request body").WithCause(err))
+		return
+	}
+
+	for _, endpoint := range c.endpoints() {
+		endpoint.SetMaintenanceEnabled(body.Enabled)
+	}
+	if body.Enabled {
+		c.Logger.Warn(req.Context(), correlationId, "Maintenance mode was enabled")
+	} else {
+		c.Logger.Info(req.Context(), correlationId, "Maintenance mode was disabled")
+	}
+
+	c.SendResult(res, req, map[string]bool{"enabled": body.Enabled}, nil)
+}
+
+func (c *AdminRestService) logLevels() map[string]any {
+	loggers := make(map[string]string)
+	if c.references2 != nil {
+		for _, reference := range findReferencedComponents(c.references2) {
+			if logger, ok := reference.component.(clog.ILogger); ok {
+				loggers[reference.name] = clog.LevelConverter.ToString(logger.Level())
+			}
+		}
+	}
+	return map[string]any{
+		"level":   clog.LevelConverter.ToString(c.Logger.Level()),
+		"loggers": loggers,
+	}
+}
+
+func (c *AdminRestService) referencedLoggers() []clog.ILogger {
+	loggers := make([]clog.ILogger, 0)
+	if c.references2 == nil {
+		return loggers
+	}
+	for _, component := range c.references2.GetOptional(crefer.NewDescriptor("*", "logger", "*", "*", "*")) {
+		if logger, ok := component.(clog.ILogger); ok {
+			loggers = append(loggers, logger)
+		}
+	}
+	return loggers
+}
+
+// endpoints gets the service endpoint and referenced HTTP endpoints.
+func (c *AdminRestService) endpoints() []*HttpEndpoint {
+	endpoints := []*HttpEndpoint{c.Endpoint}
+	if c.references2 == nil {
+		return endpoints
+	}
+	for _, component := range c.references2.GetAll() {
+		if endpoint, ok := component.(*HttpEndpoint); ok && endpoint != c.Endpoint {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// RedactConfig method creates a copy of configuration parameters with secrets replaced by RedactedValue.
+// It redacts values in credential(s) sections, values of keys that look like passwords, secrets,
+// tokens or keys, and passwords in connection URIs.
+//	Parameters:
+//		- config  *cconf.ConfigParams  configuration parameters to redact.
+//	Returns: *cconf.ConfigParams a redacted copy
+func RedactConfig(config *cconf.ConfigParams) *cconf.ConfigParams {
+	result := cconf.NewEmptyConfigParams()
+	if config == nil {
+		return result
+	}
+	for _, key := range config.Keys() {
+		value := config.GetAsString(key)
+		if isSecretConfigKey(key) {
+			value = RedactedValue
+		} else {
+			value = redactUriPassword(value)
+		}
+		result.Put(key, value)
+	}
+	return result
+}
+
+var secretConfigKeyParts = []string{"password", "secret", "token", "access_key", "api_key", "private_key"}
+
+func isSecretConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, section := range strings.Split(key, ".") {
+		if section == "credential" || section == "credentials" {
+			return true
+		}
+	}
+	for _, part := range secretConfigKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactUriPassword(value string) string {
+	schemeEnd := strings.Index(value, "://")
+	if schemeEnd < 0 {
+		return value
+	}
+	rest := value[schemeEnd+3:]
+	userInfoEnd := strings.Index(rest, "@")
+	if userInfoEnd < 0 || strings.ContainsAny(rest[:userInfoEnd], "/?#") {
+		return value
+	}
+	passwordStart := strings.Index(rest[:userInfoEnd], ":")
+	if passwordStart < 0 {
+		return value
+	}
+	return value[:schemeEnd+3] + rest[:passwordStart+1] + RedactedValue + rest[userInfoEnd:]
+}
+
+func parseLogLevel(value string) (clog.LevelType, bool) {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "0", "NOTHING", "NONE", "1", "FATAL", "2", "ERROR", "3", "WARN", "WARNING",
+		"4", "INFO", "5", "DEBUG", "6", "TRACE":
+		return clog.LevelConverter.ToLogLevel(value), true
+	}
+	return clog.LevelNone, false
+}
+
+// adminAuthorize allows requests of signed in users in "admin" role, as auth.RoleAuthManager.Admin does.
+func adminAuthorize(res http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var user *cdata.AnyValueMap
+	switch value := req.Context().Value(PipAuthUser).(type) {
+	case cdata.AnyValueMap:
+		user = &value
+	case *cdata.AnyValueMap:
+		user = value
+	}
+	if user == nil {
+		HttpResponseSender.SendError(res, req,
+			cerr.NewUnauthorizedError("", "NOT_SIGNED",
+				"User must be signed in to perform this operation").WithStatus(http.StatusUnauthorized))
+		return
+	}
+
+	if roles := user.GetAsArray(string(PipAuthRoles)); roles != nil {
+		for _, role := range roles.Value() {
+			if role, ok := role.(string); ok && role == string(PipAuthAdmin) {
+				next.ServeHTTP(res, req)
+				return
+			}
+		}
+	}
+	HttpResponseSender.SendError(res, req,
+		cerr.NewUnauthorizedError("", "NOT_IN_ROLE",
+			"User must be admin to perform this operation").
+			WithDetails(string(PipAuthRoles), []string{string(PipAuthAdmin)}).WithStatus(http.StatusForbidden))
+}
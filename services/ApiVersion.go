@@ -0,0 +1,128 @@
+package services
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AcceptVersionHeader = "Accept-Version"
+	DeprecationHeader   = "Deprecation"
+	SunsetHeader        = "Sunset"
+)
+
+// Ways to select an API version of a request.
+const (
+	// VersionSelectionPath selects versions by a route prefix, i.e. "/v1/dummies".
+	VersionSelectionPath = "path"
+	// VersionSelectionHeader selects versions by "Accept-Version" header.
+	VersionSelectionHeader = "header"
+	// VersionSelectionMediaType selects versions by "Accept" header, i.e. "application/json; version=1"
+	// or "application/vnd.mycompany.v1+json".
+	VersionSelectionMediaType = "media_type"
+)
+
+// ApiVersion describes a version of service routes and how requests select it.
+type ApiVersion struct {
+	// Version is a version name, i.e. "v1". Leading "v" is ignored when versions are compared.
+	Version string
+	// Selection is a way to select the version: "path", "header" or "media_type".
+	Selection string
+	// Default is true when the version serves requests that don't select a version.
+	Default bool
+	// Deprecated is true to send "Deprecation" header with responses.
+	Deprecated bool
+	// DeprecationDate is a time when the version was deprecated.
+	DeprecationDate time.Time
+	// SunsetDate is a time when the version stops working, sent in "Sunset" header (RFC 8594).
+	SunsetDate time.Time
+}
+
+// NewApiVersion creates a new API version selected by the route path.
+//
+//	Parameters:
+//		- version string a version name, i.e. "v1"
+//	Returns: *ApiVersion
+func NewApiVersion(version string) *ApiVersion {
+	return &ApiVersion{
+		Version:   version,
+		Selection: VersionSelectionPath,
+	}
+}
+
+// IsSelectedByPath checks if the version is selected by a route prefix.
+//
+//	Returns: bool
+func (c *ApiVersion) IsSelectedByPath() bool {
+	return c.Selection == "" || c.Selection == VersionSelectionPath
+}
+
+// Matches checks if a request selects the version. Path versions always match,
+// as they are selected by routes.
+//
+//	Parameters:
+//		- req *http.Request a HTTP request object.
+//	Returns: bool true if the request is served by the version
+func (c *ApiVersion) Matches(req *http.Request) bool {
+	if c.IsSelectedByPath() {
+		return true
+	}
+	requested := GetRequestVersion(req, c.Selection)
+	if requested == "" {
+		return c.Default
+	}
+	return normalizeVersion(requested) == normalizeVersion(c.Version)
+}
+
+// setHeaders sets "Deprecation" and "Sunset" headers of deprecated versions.
+func (c *ApiVersion) setHeaders(res http.ResponseWriter) {
+	if c.Deprecated || !c.DeprecationDate.IsZero() {
+		if c.DeprecationDate.IsZero() {
+			res.Header().Set(DeprecationHeader, "true")
+		} else {
+			res.Header().Set(DeprecationHeader, "@"+strconv.FormatInt(c.DeprecationDate.Unix(), 10))
+		}
+	}
+	if !c.SunsetDate.IsZero() {
+		res.Header().Set(SunsetHeader, c.SunsetDate.UTC().Format(http.TimeFormat))
+	}
+}
+
+// GetRequestVersion gets an API version requested by "Accept-Version" header
+// or by "Accept" media type, depending on the selection.
+//
+//	Parameters:
+//		- req       *http.Request a HTTP request object.
+//		- selection string "header" or "media_type"
+//	Returns: string the requested version or empty string
+func GetRequestVersion(req *http.Request, selection string) string {
+	switch selection {
+	case VersionSelectionHeader:
+		return strings.TrimSpace(req.Header.Get(AcceptVersionHeader))
+	case VersionSelectionMediaType:
+		for _, part := range strings.Split(req.Header.Get("Accept"), ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+			if version, ok := params["version"]; ok {
+				return version
+			}
+			// Vendor media types, i.e. application/vnd.mycompany.v1+json
+			if _, subtype, ok := strings.Cut(mediaType, "/"); ok && strings.HasPrefix(subtype, "vnd.") {
+				subtype, _, _ = strings.Cut(subtype, "+")
+				if index := strings.LastIndex(subtype, "."); index > 0 {
+					return subtype[index+1:]
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func normalizeVersion(version string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v")
+}
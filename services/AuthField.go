@@ -0,0 +1,24 @@
+package services
+
+import (
+	"net/http"
+)
+
+// AuthField is a key of the authenticated user values stored in a request context.
+// Values are set by authentication interceptors and checked by auth managers.
+type AuthField string
+
+const PipAuthUser AuthField = "user"
+const PipAuthUserId AuthField = "user_id"
+const PipAuthAdmin AuthField = "admin"
+const PipAuthRoles AuthField = "roles"
+
+// requestUserId gets id of the user that sent the request. It is the authenticated user id
+// set by authentication interceptors or, for requests that are not authenticated yet,
+// the "Authorization" header that separates different credentials.
+func requestUserId(req *http.Request) string {
+	if userId, ok := req.Context().Value(PipAuthUserId).(string); ok && userId != "" {
+		return userId
+	}
+	return req.Header.Get("Authorization")
+}
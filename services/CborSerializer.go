@@ -0,0 +1,975 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const CborContentType = "application/cbor"
+
+const (
+	cborMajorUint   = 0
+	cborMajorNegInt = 1
+	cborMajorBytes  = 2
+	cborMajorText   = 3
+	cborMajorArray  = 4
+	cborMajorMap    = 5
+	cborMajorTag    = 6
+	cborMajorSimple = 7
+
+	cborFalse     = 0xf4
+	cborTrue      = 0xf5
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+	cborFloat16   = 0xf9
+	cborFloat32   = 0xfa
+	cborFloat64   = 0xfb
+	cborBreak     = 0xff
+
+	cborIndefinite = 31
+
+	cborTagDateTime = 0
+	cborTagEpoch    = 1
+
+	cborMaxDepth = 1000
+)
+
+var (
+	cborTimeType          = reflect.TypeOf(time.Time{})
+	cborJsonNumberType    = reflect.TypeOf(json.Number(""))
+	cborJsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	cborUnmarshalerType   = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// CborSerializer encodes and decodes values in CBOR format ("application/cbor", RFC 8949).
+// Struct fields are named the same way as in JSON using "json" field tags,
+// so the same data objects can be exchanged in both formats.
+// Time values are encoded as RFC 3339 strings (tag 0). Types that implement only
+// json.Marshaler or json.Unmarshaler are converted through their JSON representation.
+type CborSerializer struct {
+}
+
+// NewCborSerializer creates a new CBOR serializer.
+//
+//	Returns: *CborSerializer
+func NewCborSerializer() *CborSerializer {
+	return &CborSerializer{}
+}
+
+// ContentType returns "application/cbor" media type.
+func (c *CborSerializer) ContentType() string {
+	return CborContentType
+}
+
+// Serialize encodes the value as CBOR.
+//
+//	Parameters:
+//		- value any a value to encode
+//	Returns: []byte, error encoded data or an error
+func (c *CborSerializer) Serialize(value any) ([]byte, error) {
+	encoder := &cborEncoder{}
+	if err := encoder.encode(reflect.ValueOf(value), 0); err != nil {
+		return nil, err
+	}
+	return encoder.buf, nil
+}
+
+// Deserialize decodes CBOR data.
+//
+//	Parameters:
+//		- data   []byte CBOR data
+//		- target any    a pointer to a value to decode the data to
+//	Returns: error
+func (c *CborSerializer) Deserialize(data []byte, target any) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return errors.New("cbor: target must be a non-nil pointer")
+	}
+	decoder := &cborDecoder{data: data}
+	if err := decoder.decode(value.Elem(), 0); err != nil {
+		return err
+	}
+	if decoder.pos != len(decoder.data) {
+		return errors.New("cbor: unexpected data after the top-level value")
+	}
+	return nil
+}
+
+// Struct fields
+
+type cborField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+var cborFieldsCache sync.Map
+
+func cborStructFields(t reflect.Type) []cborField {
+	if fields, ok := cborFieldsCache.Load(t); ok {
+		return fields.([]cborField)
+	}
+
+	type candidate struct {
+		cborField
+		depth int
+	}
+	candidates := make([]candidate, 0)
+
+	var collect func(t reflect.Type, index []int, depth int)
+	collect = func(t reflect.Type, index []int, depth int) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, options, _ := strings.Cut(tag, ",")
+			fieldIndex := append(append([]int{}, index...), i)
+
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			// Fields of embedded structs without names are promoted like in JSON
+			if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+				collect(fieldType, fieldIndex, depth+1)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			candidates = append(candidates, candidate{
+				cborField: cborField{
+					name:      name,
+					index:     fieldIndex,
+					omitEmpty: strings.Contains(","+options+",", ",omitempty,"),
+				},
+				depth: depth,
+			})
+		}
+	}
+	collect(t, nil, 0)
+
+	// Shallower fields hide promoted fields with the same name
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].depth < candidates[j].depth })
+	names := make(map[string]bool)
+	fields := make([]cborField, 0, len(candidates))
+	for _, candidate := range candidates {
+		if names[candidate.name] {
+			continue
+		}
+		names[candidate.name] = true
+		fields = append(fields, candidate.cborField)
+	}
+
+	cborFieldsCache.Store(t, fields)
+	return fields
+}
+
+// Encoder
+
+type cborEncoder struct {
+	buf []byte
+}
+
+func (c *cborEncoder) writeHead(major byte, arg uint64) {
+	major <<= 5
+	switch {
+	case arg < 24:
+		c.buf = append(c.buf, major|byte(arg))
+	case arg <= math.MaxUint8:
+		c.buf = append(c.buf, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		c.writeUint16(major|25, uint16(arg))
+	case arg <= math.MaxUint32:
+		c.writeUint32(major|26, uint32(arg))
+	default:
+		c.writeUint64(major|27, arg)
+	}
+}
+
+// writeUint16, writeUint32 and writeUint64 write the initial byte followed by big-endian argument.
+func (c *cborEncoder) writeUint16(head byte, arg uint16) {
+	var bytes [8]byte
+	binary.BigEndian.PutUint16(bytes[:], arg)
+	c.buf = append(append(c.buf, head), bytes[:2]...)
+}
+
+func (c *cborEncoder) writeUint32(head byte, arg uint32) {
+	var bytes [8]byte
+	binary.BigEndian.PutUint32(bytes[:], arg)
+	c.buf = append(append(c.buf, head), bytes[:4]...)
+}
+
+func (c *cborEncoder) writeUint64(head byte, arg uint64) {
+	var bytes [8]byte
+	binary.BigEndian.PutUint64(bytes[:], arg)
+	c.buf = append(append(c.buf, head), bytes[:]...)
+}
+
+func (c *cborEncoder) writeInt(value int64) {
+	if value >= 0 {
+		c.writeHead(cborMajorUint, uint64(value))
+	} else {
+		c.writeHead(cborMajorNegInt, uint64(-(value + 1)))
+	}
+}
+
+func (c *cborEncoder) writeFloat(value float64, bits int) {
+	if bits == 32 {
+		c.writeUint32(cborFloat32, math.Float32bits(float32(value)))
+	} else {
+		c.writeUint64(cborFloat64, math.Float64bits(value))
+	}
+}
+
+func (c *cborEncoder) writeString(value string) {
+	c.writeHead(cborMajorText, uint64(len(value)))
+	c.buf = append(c.buf, value...)
+}
+
+func (c *cborEncoder) encode(value reflect.Value, depth int) error {
+	if depth > cborMaxDepth {
+		return errors.New("cbor: max nesting depth exceeded")
+	}
+	if !value.IsValid() {
+		c.buf = append(c.buf, cborNull)
+		return nil
+	}
+
+	valueType := value.Type()
+	switch {
+	case valueType == cborTimeType:
+		c.writeHead(cborMajorTag, cborTagDateTime)
+		c.writeString(value.Interface().(time.Time).Format(time.RFC3339Nano))
+		return nil
+	case valueType == cborJsonNumberType:
+		return c.encodeJsonNumber(json.Number(value.String()))
+	case value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface:
+		if value.IsNil() {
+			c.buf = append(c.buf, cborNull)
+			return nil
+		}
+		// Elements of pointers are addressable, so marshalers with pointer receivers are found below
+		return c.encode(value.Elem(), depth)
+	case valueType.Implements(cborJsonMarshalerType):
+		return c.encodeJsonMarshaler(value.Interface().(json.Marshaler), depth)
+	case value.CanAddr() && reflect.PointerTo(valueType).Implements(cborJsonMarshalerType):
+		return c.encodeJsonMarshaler(value.Addr().Interface().(json.Marshaler), depth)
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			c.buf = append(c.buf, cborTrue)
+		} else {
+			c.buf = append(c.buf, cborFalse)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c.writeInt(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		c.writeHead(cborMajorUint, value.Uint())
+	case reflect.Float32:
+		c.writeFloat(value.Float(), 32)
+	case reflect.Float64:
+		c.writeFloat(value.Float(), 64)
+	case reflect.String:
+		c.writeString(value.String())
+	case reflect.Slice:
+		if value.IsNil() {
+			c.buf = append(c.buf, cborNull)
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		if valueType.Elem().Kind() == reflect.Uint8 {
+			c.writeHead(cborMajorBytes, uint64(value.Len()))
+			for i := 0; i < value.Len(); i++ {
+				c.buf = append(c.buf, byte(value.Index(i).Uint()))
+			}
+			return nil
+		}
+		c.writeHead(cborMajorArray, uint64(value.Len()))
+		for i := 0; i < value.Len(); i++ {
+			if err := c.encode(value.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			c.buf = append(c.buf, cborNull)
+			return nil
+		}
+		return c.encodeMap(value, depth)
+	case reflect.Struct:
+		return c.encodeStruct(value, depth)
+	default:
+		return fmt.Errorf("cbor: unsupported type %s", valueType)
+	}
+	return nil
+}
+
+func (c *cborEncoder) encodeJsonNumber(number json.Number) error {
+	if value, err := number.Int64(); err == nil {
+		c.writeInt(value)
+		return nil
+	}
+	value, err := number.Float64()
+	if err != nil {
+		return fmt.Errorf("cbor: invalid number %q", number)
+	}
+	c.writeFloat(value, 64)
+	return nil
+}
+
+func (c *cborEncoder) encodeJsonMarshaler(marshaler json.Marshaler, depth int) error {
+	data, err := marshaler.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	var value any
+	if err = decoder.Decode(&value); err != nil {
+		return err
+	}
+	return c.encode(reflect.ValueOf(value), depth+1)
+}
+
+func (c *cborEncoder) encodeMap(value reflect.Value, depth int) error {
+	keys := value.MapKeys()
+	// Keep the output deterministic for string keys
+	if value.Type().Key().Kind() == reflect.String {
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	}
+
+	c.writeHead(cborMajorMap, uint64(len(keys)))
+	for _, key := range keys {
+		if err := c.encode(key, depth+1); err != nil {
+			return err
+		}
+		if err := c.encode(value.MapIndex(key), depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *cborEncoder) encodeStruct(value reflect.Value, depth int) error {
+	fields := cborStructFields(value.Type())
+	values := make([]reflect.Value, 0, len(fields))
+	names := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		fieldValue, ok := cborFieldByIndex(value, field.index, false)
+		if !ok || field.omitEmpty && cborIsEmptyValue(fieldValue) {
+			continue
+		}
+		values = append(values, fieldValue)
+		names = append(names, field.name)
+	}
+
+	c.writeHead(cborMajorMap, uint64(len(values)))
+	for i, fieldValue := range values {
+		c.writeString(names[i])
+		if err := c.encode(fieldValue, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cborFieldByIndex returns a struct field walking through embedded pointers.
+// Nil embedded pointers are allocated when alloc is set, otherwise the field is skipped.
+func cborFieldByIndex(value reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, fieldIndex := range index {
+		if i > 0 && value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				if !alloc || !value.CanSet() {
+					return reflect.Value{}, false
+				}
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(fieldIndex)
+	}
+	return value, true
+}
+
+func cborIsEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return value.IsNil()
+	}
+	return false
+}
+
+// Decoder
+
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+var errCborUnexpectedEnd = errors.New("cbor: unexpected end of data")
+
+func (c *cborDecoder) readHead() (major byte, info byte, arg uint64, err error) {
+	if c.pos >= len(c.data) {
+		return 0, 0, 0, errCborUnexpectedEnd
+	}
+	initial := c.data[c.pos]
+	c.pos++
+	major, info = initial>>5, initial&0x1f
+
+	size := 0
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	case info == cborIndefinite:
+		if major == cborMajorUint || major == cborMajorNegInt || major == cborMajorTag {
+			return 0, 0, 0, fmt.Errorf("cbor: invalid indefinite length for major type %d", major)
+		}
+		return major, info, 0, nil
+	default:
+		return 0, 0, 0, fmt.Errorf("cbor: invalid additional information %d", info)
+	}
+
+	if c.pos+size > len(c.data) {
+		return 0, 0, 0, errCborUnexpectedEnd
+	}
+	buf := c.data[c.pos : c.pos+size]
+	c.pos += size
+	switch size {
+	case 1:
+		arg = uint64(buf[0])
+	case 2:
+		arg = uint64(binary.BigEndian.Uint16(buf))
+	case 4:
+		arg = uint64(binary.BigEndian.Uint32(buf))
+	default:
+		arg = binary.BigEndian.Uint64(buf)
+	}
+	return major, info, arg, nil
+}
+
+// checkLength prevents huge allocations for lengths that can't fit into the remaining data.
+func (c *cborDecoder) checkLength(length uint64) error {
+	if length > uint64(len(c.data)-c.pos) {
+		return errCborUnexpectedEnd
+	}
+	return nil
+}
+
+func (c *cborDecoder) isBreak() bool {
+	if c.pos < len(c.data) && c.data[c.pos] == cborBreak {
+		c.pos++
+		return true
+	}
+	return false
+}
+
+func (c *cborDecoder) readString(major byte, info byte, length uint64) ([]byte, error) {
+	if info != cborIndefinite {
+		if err := c.checkLength(length); err != nil {
+			return nil, err
+		}
+		result := make([]byte, length)
+		copy(result, c.data[c.pos:c.pos+int(length)])
+		c.pos += int(length)
+		return result, nil
+	}
+
+	// Indefinite strings are sequences of definite chunks of the same type
+	result := make([]byte, 0)
+	for !c.isBreak() {
+		chunkMajor, chunkInfo, chunkLength, err := c.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if chunkMajor != major || chunkInfo == cborIndefinite {
+			return nil, errors.New("cbor: invalid indefinite string chunk")
+		}
+		if err = c.checkLength(chunkLength); err != nil {
+			return nil, err
+		}
+		result = append(result, c.data[c.pos:c.pos+int(chunkLength)]...)
+		c.pos += int(chunkLength)
+	}
+	return result, nil
+}
+
+func (c *cborDecoder) readFloat(info byte, arg uint64) float64 {
+	switch info {
+	case 25:
+		return cborHalfToFloat(uint16(arg))
+	case 26:
+		return float64(math.Float32frombits(uint32(arg)))
+	default:
+		return math.Float64frombits(arg)
+	}
+}
+
+func cborHalfToFloat(half uint16) float64 {
+	exp := int(half>>10) & 0x1f
+	mant := float64(half & 0x3ff)
+	var value float64
+	switch exp {
+	case 0:
+		value = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			value = math.Inf(1)
+		} else {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mant+1024, exp-25)
+	}
+	if half&0x8000 != 0 {
+		value = -value
+	}
+	return value
+}
+
+// decodeAny decodes the next item into generic Go values:
+// int64, uint64, float64, bool, string, []byte, []any, map[string]any or nil.
+func (c *cborDecoder) decodeAny(depth int) (any, error) {
+	if depth > cborMaxDepth {
+		return nil, errors.New("cbor: max nesting depth exceeded")
+	}
+	major, info, arg, err := c.readHead()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborMajorUint:
+		if arg <= math.MaxInt64 {
+			return int64(arg), nil
+		}
+		return arg, nil
+	case cborMajorNegInt:
+		if arg > math.MaxInt64 {
+			return nil, errors.New("cbor: negative integer overflows int64")
+		}
+		return -1 - int64(arg), nil
+	case cborMajorBytes:
+		return c.readString(major, info, arg)
+	case cborMajorText:
+		value, err := c.readString(major, info, arg)
+		return string(value), err
+	case cborMajorArray:
+		result := make([]any, 0)
+		for i := uint64(0); info == cborIndefinite || i < arg; i++ {
+			if info == cborIndefinite && c.isBreak() {
+				break
+			}
+			item, err := c.decodeAny(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, item)
+		}
+		return result, nil
+	case cborMajorMap:
+		result := make(map[string]any)
+		for i := uint64(0); info == cborIndefinite || i < arg; i++ {
+			if info == cborIndefinite && c.isBreak() {
+				break
+			}
+			key, err := c.decodeAny(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			value, err := c.decodeAny(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			if name, ok := key.(string); ok {
+				result[name] = value
+			} else {
+				result[fmt.Sprint(key)] = value
+			}
+		}
+		return result, nil
+	case cborMajorTag:
+		// Tagged values are returned as is, i.e. date/time tags as strings or numbers
+		return c.decodeAny(depth + 1)
+	default:
+		switch info {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22, 23:
+			return nil, nil
+		case 25, 26, 27:
+			return c.readFloat(info, arg), nil
+		case cborIndefinite:
+			return nil, errors.New("cbor: unexpected break")
+		}
+		return nil, fmt.Errorf("cbor: unsupported simple value %d", arg)
+	}
+}
+
+func (c *cborDecoder) decode(value reflect.Value, depth int) error {
+	if depth > cborMaxDepth {
+		return errors.New("cbor: max nesting depth exceeded")
+	}
+	if c.pos >= len(c.data) {
+		return errCborUnexpectedEnd
+	}
+
+	// Null leaves non-nullable values unchanged like in JSON
+	if initial := c.data[c.pos]; initial == cborNull || initial == cborUndefined {
+		c.pos++
+		switch value.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+			value.Set(reflect.Zero(value.Type()))
+		}
+		return nil
+	}
+
+	valueType := value.Type()
+	switch {
+	case value.Kind() == reflect.Pointer:
+		if value.IsNil() {
+			value.Set(reflect.New(valueType.Elem()))
+		}
+		return c.decode(value.Elem(), depth)
+	case valueType == cborTimeType:
+		return c.decodeTime(value)
+	case value.CanAddr() && reflect.PointerTo(valueType).Implements(cborUnmarshalerType):
+		return c.decodeJsonUnmarshaler(value.Addr().Interface().(json.Unmarshaler), depth)
+	case value.Kind() == reflect.Interface:
+		if value.NumMethod() != 0 {
+			return fmt.Errorf("cbor: cannot decode into non-empty interface %s", valueType)
+		}
+		result, err := c.decodeAny(depth)
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			value.Set(reflect.Zero(valueType))
+		} else {
+			value.Set(reflect.ValueOf(result))
+		}
+		return nil
+	}
+
+	major, info, arg, err := c.readHead()
+	if err != nil {
+		return err
+	}
+
+	switch major {
+	case cborMajorUint:
+		return c.setUint(value, arg)
+	case cborMajorNegInt:
+		if arg > math.MaxInt64 {
+			return errors.New("cbor: negative integer overflows int64")
+		}
+		return c.setInt(value, -1-int64(arg))
+	case cborMajorBytes, cborMajorText:
+		buf, err := c.readString(major, info, arg)
+		if err != nil {
+			return err
+		}
+		return c.setString(value, major, buf)
+	case cborMajorArray:
+		return c.decodeArray(value, info, arg, depth)
+	case cborMajorMap:
+		return c.decodeMap(value, info, arg, depth)
+	case cborMajorTag:
+		return c.decode(value, depth+1)
+	default:
+		switch info {
+		case 20, 21:
+			if value.Kind() != reflect.Bool {
+				return c.typeError("bool", valueType)
+			}
+			value.SetBool(info == 21)
+			return nil
+		case 25, 26, 27:
+			return c.setFloat(value, c.readFloat(info, arg))
+		}
+		return fmt.Errorf("cbor: unsupported simple value %d", arg)
+	}
+}
+
+func (c *cborDecoder) typeError(cborType string, valueType reflect.Type) error {
+	return fmt.Errorf("cbor: cannot decode %s into Go value of type %s", cborType, valueType)
+}
+
+func (c *cborDecoder) setUint(value reflect.Value, arg uint64) error {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if arg > math.MaxInt64 || value.OverflowInt(int64(arg)) {
+			return fmt.Errorf("cbor: number %d overflows %s", arg, value.Type())
+		}
+		value.SetInt(int64(arg))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if value.OverflowUint(arg) {
+			return fmt.Errorf("cbor: number %d overflows %s", arg, value.Type())
+		}
+		value.SetUint(arg)
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(float64(arg))
+	default:
+		return c.typeError("integer", value.Type())
+	}
+	return nil
+}
+
+func (c *cborDecoder) setInt(value reflect.Value, number int64) error {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value.OverflowInt(number) {
+			return fmt.Errorf("cbor: number %d overflows %s", number, value.Type())
+		}
+		value.SetInt(number)
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(float64(number))
+	default:
+		return c.typeError("negative integer", value.Type())
+	}
+	return nil
+}
+
+func (c *cborDecoder) setFloat(value reflect.Value, number float64) error {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(number)
+		return nil
+	}
+	// Integral floats are accepted for integer fields since some encoders shrink numbers
+	if number != math.Trunc(number) || math.IsInf(number, 0) || math.IsNaN(number) {
+		return c.typeError("float", value.Type())
+	}
+	if number < 0 {
+		return c.setInt(value, int64(number))
+	}
+	return c.setUint(value, uint64(number))
+}
+
+func (c *cborDecoder) setString(value reflect.Value, major byte, buf []byte) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(string(buf))
+		return nil
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			if major == cborMajorText {
+				// Byte slices are sent as base64 strings in JSON
+				decoded, err := base64.StdEncoding.DecodeString(string(buf))
+				if err != nil {
+					return err
+				}
+				buf = decoded
+			}
+			value.SetBytes(buf)
+			return nil
+		}
+	case reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 && major == cborMajorBytes {
+			reflect.Copy(value, reflect.ValueOf(buf))
+			return nil
+		}
+	}
+	if major == cborMajorBytes {
+		return c.typeError("byte string", value.Type())
+	}
+	return c.typeError("text string", value.Type())
+}
+
+func (c *cborDecoder) decodeArray(value reflect.Value, info byte, length uint64, depth int) error {
+	indefinite := info == cborIndefinite
+	if !indefinite {
+		if err := c.checkLength(length); err != nil {
+			return err
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Slice:
+		result := reflect.MakeSlice(value.Type(), 0, int(length))
+		for i := uint64(0); indefinite || i < length; i++ {
+			if indefinite && c.isBreak() {
+				break
+			}
+			item := reflect.New(value.Type().Elem()).Elem()
+			if err := c.decode(item, depth+1); err != nil {
+				return err
+			}
+			result = reflect.Append(result, item)
+		}
+		value.Set(result)
+		return nil
+	case reflect.Array:
+		i := 0
+		for ; indefinite || uint64(i) < length; i++ {
+			if indefinite && c.isBreak() {
+				break
+			}
+			if i < value.Len() {
+				if err := c.decode(value.Index(i), depth+1); err != nil {
+					return err
+				}
+			} else if _, err := c.decodeAny(depth + 1); err != nil {
+				return err
+			}
+		}
+		for ; i < value.Len(); i++ {
+			value.Index(i).Set(reflect.Zero(value.Type().Elem()))
+		}
+		return nil
+	}
+	return c.typeError("array", value.Type())
+}
+
+func (c *cborDecoder) decodeMap(value reflect.Value, info byte, length uint64, depth int) error {
+	indefinite := info == cborIndefinite
+	if !indefinite {
+		if err := c.checkLength(length); err != nil {
+			return err
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Map:
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		for i := uint64(0); indefinite || i < length; i++ {
+			if indefinite && c.isBreak() {
+				break
+			}
+			key := reflect.New(value.Type().Key()).Elem()
+			if err := c.decode(key, depth+1); err != nil {
+				return err
+			}
+			item := reflect.New(value.Type().Elem()).Elem()
+			if err := c.decode(item, depth+1); err != nil {
+				return err
+			}
+			value.SetMapIndex(key, item)
+		}
+		return nil
+	case reflect.Struct:
+		fields := cborStructFields(value.Type())
+		for i := uint64(0); indefinite || i < length; i++ {
+			if indefinite && c.isBreak() {
+				break
+			}
+			var name string
+			if err := c.decode(reflect.ValueOf(&name).Elem(), depth+1); err != nil {
+				return err
+			}
+			field, ok := cborFindField(fields, name)
+			if !ok {
+				// Unknown fields are skipped
+				if _, err := c.decodeAny(depth + 1); err != nil {
+					return err
+				}
+				continue
+			}
+			fieldValue, ok := cborFieldByIndex(value, field.index, true)
+			if !ok {
+				// Fields of unexported nil embedded pointers can't be set and are skipped
+				if _, err := c.decodeAny(depth + 1); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := c.decode(fieldValue, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return c.typeError("map", value.Type())
+}
+
+func cborFindField(fields []cborField, name string) (cborField, bool) {
+	for _, field := range fields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	// Names are matched case-insensitively like in JSON
+	for _, field := range fields {
+		if strings.EqualFold(field.name, name) {
+			return field, true
+		}
+	}
+	return cborField{}, false
+}
+
+func (c *cborDecoder) decodeTime(value reflect.Value) error {
+	result, err := c.decodeAny(0)
+	if err != nil {
+		return err
+	}
+	switch v := result.(type) {
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(t))
+	case int64:
+		value.Set(reflect.ValueOf(time.Unix(v, 0).UTC()))
+	case uint64:
+		value.Set(reflect.ValueOf(time.Unix(int64(v), 0).UTC()))
+	case float64:
+		seconds, fraction := math.Modf(v)
+		value.Set(reflect.ValueOf(time.Unix(int64(seconds), int64(fraction*1e9)).UTC()))
+	default:
+		return c.typeError(fmt.Sprintf("%T", result), value.Type())
+	}
+	return nil
+}
+
+func (c *cborDecoder) decodeJsonUnmarshaler(unmarshaler json.Unmarshaler, depth int) error {
+	result, err := c.decodeAny(depth)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return unmarshaler.UnmarshalJSON(data)
+}
@@ -1,16 +1,13 @@
 package services
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"io/ioutil"
 	"net/http"
 
-	"github.com/gorilla/mux"
 	ccomands "github.com/pip-services3-gox/pip-services3-commons-gox/commands"
 	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
 	crun "github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	cvalid "github.com/pip-services3-gox/pip-services3-commons-gox/validate"
 )
 
 // CommandableHttpService abstract service that receives remove calls via HTTP/REST protocol
@@ -127,6 +124,11 @@ func (c *CommandableHttpService) Register() {
 	for index := 0; index < len(commands); index++ {
 		command := commands[index]
 
+		var properties []*cvalid.PropertySchema
+		if cmd, ok := command.(*ccomands.Command); ok {
+			properties = objectSchemaProperties(cmd.GetSchema())
+		}
+
 		route := command.Name()
 		if route[0] != "/"[0] {
 			route = "/" + route
@@ -134,27 +136,22 @@ func (c *CommandableHttpService) Register() {
 
 		c.RegisterRoute(http.MethodPost, route, nil, func(res http.ResponseWriter, req *http.Request) {
 
-			// Make copy of request
-			bodyBuf, bodyErr := ioutil.ReadAll(req.Body)
-			if bodyErr != nil {
+			if _, bodyErr := GetRequestBody(req); bodyErr != nil {
 				HttpResponseSender.SendError(res, req, bodyErr)
 				return
 			}
-			_ = req.Body.Close()
-			req.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBuf))
-			//-------------------------
 			// TODO:: think about marshaling and error
+			// The cached body is copied, so adding parameters doesn't change it
 			var params map[string]any = make(map[string]any, 0)
-			json.Unmarshal(bodyBuf, &params)
-
-			urlParams := req.URL.Query()
-			for k, v := range urlParams {
-				params[k] = v[0]
-			}
-			for k, v := range mux.Vars(req) {
-				params[k] = v
+			body, _ := GetRequestBodyValue(req)
+			if values, ok := body.(map[string]any); ok {
+				for k, v := range values {
+					params[k] = v
+				}
 			}
 
+			putRequestParams(params, req, properties)
+
 			correlationId := c.GetCorrelationId(req)
 			args := crun.NewParametersFromValue(params)
 			timing := c.Instrument(req.Context(), correlationId, c.BaseRoute+"."+command.Name())
@@ -167,7 +164,10 @@ func (c *CommandableHttpService) Register() {
 
 	if c.SwaggerAuto {
 		var swaggerConfig = c.config.GetSection("swagger")
-		var doc = NewCommandableSwaggerDocument(c.BaseRoute, swaggerConfig, commands)
+		var doc = NewCommandableSwaggerDocument(c.versionedBaseRoute(), swaggerConfig, commands)
+		if c.ApiVersion != nil {
+			doc.InfoVersion = c.ApiVersion.Version
+		}
 		c.RegisterOpenApiSpec(doc.ToString())
 	}
 }
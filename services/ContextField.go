@@ -0,0 +1,39 @@
+package services
+
+import (
+	"net/http"
+)
+
+// ContextField is a key of the values that are stored by HTTP endpoints in a request context.
+type ContextField string
+
+const PipCorrelationId ContextField = "correlation_id"
+const PipTraceContext ContextField = "trace_context"
+const PipProblemDetails ContextField = "problem_details"
+const PipRequestBody ContextField = "request_body"
+const PipRequestParams ContextField = "request_params"
+const PipResponseProjection ContextField = "response_projection"
+const PipPagingHeaders ContextField = "paging_headers"
+
+// DefaultCorrelationIdHeaders are the request headers checked for a correlation id in order of precedence.
+var DefaultCorrelationIdHeaders = []string{"correlation_id", "X-Correlation-Id", "X-Request-Id"}
+
+// getRequestCorrelationId reads correlation id from the request context.
+// If it wasn't set by the endpoint, it falls back to the query parameters and request headers.
+func getRequestCorrelationId(req *http.Request, headers []string) string {
+	if correlationId, ok := req.Context().Value(PipCorrelationId).(string); ok && correlationId != "" {
+		return correlationId
+	}
+
+	correlationId := req.URL.Query().Get("correlation_id")
+	if correlationId != "" {
+		return correlationId
+	}
+	for _, header := range headers {
+		correlationId = req.Header.Get(header)
+		if correlationId != "" {
+			return correlationId
+		}
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cvalid "github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+)
+
+const (
+	// ValidationResultsDetail is a key of validation errors in details of a validation error.
+	ValidationResultsDetail = "results"
+
+	ParameterLocationPath  = "path"
+	ParameterLocationQuery = "query"
+	ParameterLocationBody  = "body"
+)
+
+// FieldValidationError describes a single validation result of a request parameter.
+// A list of them is sent in "results" details of validation errors with 400 status code,
+// so clients can highlight the exact fields that failed.
+type FieldValidationError struct {
+	// Path is a path to the field within its location, i.e. "key" or "content.name" in the body.
+	Path string `json:"path"`
+	// Location is where the parameter was sent: "path", "query" or "body". It is empty when unknown.
+	Location string `json:"location"`
+	// Type is a result type: "error", "warning" or "information".
+	Type string `json:"type"`
+	// Code is an error code, i.e. "VALUE_IS_NULL".
+	Code string `json:"code"`
+	// Message is a human-readable description of the problem.
+	Message string `json:"message"`
+	// Expected is an expected value or type.
+	Expected any `json:"expected"`
+	// Actual is an actual value or type.
+	Actual any `json:"actual"`
+}
+
+// NewFieldValidationErrors converts schema validation results into field validation errors.
+// Parameters are located using the request: "body" property goes to the body,
+// route variables to the path and all other parameters to the query.
+//
+//	Parameters:
+//		- req     *http.Request            (optional) a validated request, nil when the location is unknown.
+//		- results []*cvalid.ValidationResult validation results
+//	Returns: []*FieldValidationError
+func NewFieldValidationErrors(req *http.Request, results []*cvalid.ValidationResult) []*FieldValidationError {
+	var vars map[string]string
+	if req != nil {
+		vars = mux.Vars(req)
+	}
+
+	errs := make([]*FieldValidationError, 0, len(results))
+	for _, result := range results {
+		path := result.Path()
+		location := ""
+		name, rest, _ := strings.Cut(path, ".")
+		switch {
+		case name == "body":
+			location = ParameterLocationBody
+			path = rest
+		case req == nil:
+		case vars[name] != "":
+			location = ParameterLocationPath
+		default:
+			location = ParameterLocationQuery
+		}
+
+		errs = append(errs, &FieldValidationError{
+			Path:     path,
+			Location: location,
+			Type:     validationResultTypeName(result.Type()),
+			Code:     result.Code(),
+			Message:  result.Message(),
+			Expected: result.Expected(),
+			Actual:   result.Actual(),
+		})
+	}
+	return errs
+}
+
+func validationResultTypeName(typ cvalid.ValidationResultType) string {
+	switch typ {
+	case cvalid.Error:
+		return "error"
+	case cvalid.Warning:
+		return "warning"
+	default:
+		return "information"
+	}
+}
+
+// GetFieldValidationErrors extracts field validation errors from details of an error.
+// It works both for errors created by HttpEndpoint and errors received by RestClient.
+//
+//	Parameters:
+//		- err error an error returned by validation or a remote call
+//	Returns: []*FieldValidationError field errors or nil if the error has no validation results
+func GetFieldValidationErrors(err error) []*FieldValidationError {
+	appErr, ok := err.(*cerr.ApplicationError)
+	if !ok || appErr == nil || appErr.Details == nil {
+		return nil
+	}
+
+	switch results := appErr.Details[ValidationResultsDetail].(type) {
+	case nil:
+		return nil
+	case []*FieldValidationError:
+		return results
+	case []*cvalid.ValidationResult:
+		return NewFieldValidationErrors(nil, results)
+	default:
+		// Received errors have generic details, so they are restored through JSON
+		data, jsonErr := json.Marshal(results)
+		if jsonErr != nil {
+			return nil
+		}
+		errs := make([]*FieldValidationError, 0)
+		if jsonErr = json.Unmarshal(data, &errs); jsonErr != nil {
+			return nil
+		}
+		return errs
+	}
+}
+
+// withFieldValidationErrors replaces validation results in error details,
+// which have no exported fields, with field validation errors that can be serialized.
+func withFieldValidationErrors(desc *cerr.ErrorDescription) *cerr.ErrorDescription {
+	results, ok := desc.Details[ValidationResultsDetail].([]*cvalid.ValidationResult)
+	if !ok {
+		return desc
+	}
+	details := make(map[string]any, len(desc.Details))
+	for key, value := range desc.Details {
+		details[key] = value
+	}
+	details[ValidationResultsDetail] = NewFieldValidationErrors(nil, results)
+	desc.Details = details
+	return desc
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	crefer "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	crun "github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+
+	DefaultHealthCheckTimeout = 5000
+	DefaultHealthCacheTimeout = 1000
+)
+
+// HealthCheckResult is a result of a single health check.
+type HealthCheckResult struct {
+	// Name is a name of the check, i.e. a locator of the checked component.
+	Name string `json:"name"`
+	// Status is "up" or "down".
+	Status string `json:"status"`
+	// Error describes the problem of a failed check.
+	Error string `json:"error,omitempty"`
+	// Duration is time of the check in milliseconds.
+	Duration int64 `json:"duration"`
+}
+
+// HealthReport is an aggregated result of health checks.
+type HealthReport struct {
+	// Status is "up" when all checks passed or "down" otherwise.
+	Status string `json:"status"`
+	// Time is a time when checks were performed.
+	Time time.Time `json:"time"`
+	// Checks are results of the checks.
+	Checks []*HealthCheckResult `json:"checks,omitempty"`
+}
+
+// HealthRestService is a service that reports liveness and readiness of a microservice
+// to container orchestrators (i.e. Kubernetes probes) via HTTP/REST protocol.
+//
+//	The service responds on two routes:
+//		- /health/live   200 while the process is able to respond
+//		- /health/ready  200 when all checks passed or 503 when any of them failed
+//
+//	Both routes respond with a JSON report:
+//		{
+//			"status": "up" or "down",
+//			"time":   time when checks were performed,
+//			"checks": [{ "name": ..., "status": ..., "error": ..., "duration": ... }]
+//		}
+//
+//	Readiness checks referenced components that implement IHealthCheck,
+//	and components that implement crun.IOpenable are checked to be opened.
+//	Additional checks can be added with RegisterHealthCheck.
+//
+//	Health routes are served while maintenance mode of the endpoint is enabled, so orchestrators
+//	don't restart the process. Liveness is reported as usual and readiness reports "down"
+//	with a failed "maintenance" check, so the instance stops receiving traffic.
+//
+//	Configuration parameters:
+//		- baseroute:              base route for remote URI
+//		- route:                  health route (default: "health")
+//		- dependencies:
+//			- endpoint:           override for HTTP Endpoint dependency
+//		- connection(s):
+//			- discovery_key:      (optional) a key to retrieve the connection from IDiscovery
+//			- protocol:           connection protocol: http or https
+//			- host:               host name or IP address
+//			- port:               port number
+//			- uri:                resource URI or connection string with all parameters in it
+//		- options:
+//			- check_timeout:      default timeout of a check in milliseconds (default: 5000)
+//			- cache_timeout:      time in milliseconds to reuse results of readiness checks, 0 to disable (default: 1000)
+//
+//	References:
+//		- *:logger:*:*:1.0       (optional) ILogger components to pass log messages
+//		- *:counters:*:*:1.0     (optional) ICounters components to pass collected measurements
+//		- *:discovery:*:*:1.0    (optional) IDiscovery services to resolve connection
+//		- *:endpoint:http:*:1.0  (optional) HttpEndpoint reference
+//		- *:*:*:*:*              (optional) IHealthCheck and crun.IOpenable components to check
+//
+//	see: RestService
+//	see: IHealthCheck
+//
+//	Example:
+//		service := NewHealthRestService()
+//		service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+//			"connection.protocol", "http",
+//			"connection.host", "localhost",
+//			"connection.port", 8080,
+//		))
+//		service.RegisterHealthCheck("database", 1000, myDatabaseCheck)
+//
+//		opnErr := service.Open(context.Background(), "123")
+//		if opnErr == nil {
+//			fmt.Println("The Health service is accessible at http://localhost:8080/health/ready")
+//		}
+type HealthRestService struct {
+	*RestService
+	references2  crefer.IReferences
+	route        string
+	checkTimeout int64
+	cacheTimeout int64
+	checks       []*registeredHealthCheck
+	lock         sync.Mutex
+	lastReport   *HealthReport
+	running      *healthCheckRun
+}
+
+// healthCheckRun is a run of health checks shared by concurrent probes.
+type healthCheckRun struct {
+	done   chan struct{}
+	report *HealthReport
+}
+
+// detachedContext keeps values of its parent context, but it is never cancelled with it.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (c detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (c detachedContext) Done() <-chan struct{}       { return nil }
+func (c detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key any) any           { return c.parent.Value(key) }
+
+type registeredHealthCheck struct {
+	name    string
+	timeout int64
+	check   IHealthCheck
+}
+
+// healthCheckFunc adapts functions to IHealthCheck.
+type healthCheckFunc func(ctx context.Context, correlationId string) error
+
+func (c healthCheckFunc) CheckHealth(ctx context.Context, correlationId string) error {
+	return c(ctx, correlationId)
+}
+
+// NewHealthRestService method are creates a new instance of this service.
+func NewHealthRestService() *HealthRestService {
+	c := &HealthRestService{}
+	c.RestService = InheritRestService(c)
+	c.route = "health"
+	c.checkTimeout = DefaultHealthCheckTimeout
+	c.cacheTimeout = DefaultHealthCacheTimeout
+	c.checks = make([]*registeredHealthCheck, 0)
+	return c
+}
+
+// Configure method are configures component by passing configuration parameters.
+//	Parameters:
+//		- ctx context.Context
+//		- config  *cconf.ConfigParams  configuration parameters to be set.
+func (c *HealthRestService) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.RestService.Configure(ctx, config)
+	c.route = config.GetAsStringWithDefault("route", c.route)
+	c.checkTimeout = config.GetAsLongWithDefault("options.check_timeout", c.checkTimeout)
+	c.cacheTimeout = config.GetAsLongWithDefault("options.cache_timeout", c.cacheTimeout)
+}
+
+// SetReferences method are sets references to dependent components.
+//	Parameters:
+//		- ctx context.Context
+//		- references crefer.IReferences	references to locate the component dependencies.
+func (c *HealthRestService) SetReferences(ctx context.Context, references crefer.IReferences) {
+	c.references2 = references
+	c.RestService.SetReferences(ctx, references)
+}
+
+// RegisterHealthCheck method are adds a readiness check with its own timeout.
+//	Parameters:
+//		- name     a name of the check in the report
+//		- timeout  timeout of the check in milliseconds, 0 to use the default timeout
+//		- check    a function that returns an error when the check failed
+func (c *HealthRestService) RegisterHealthCheck(name string, timeout int64,
+	check func(ctx context.Context, correlationId string) error) {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.checks = append(c.checks, &registeredHealthCheck{
+		name:    name,
+		timeout: timeout,
+		check:   healthCheckFunc(check),
+	})
+}
+
+// Register method are registers all service routes in HTTP endpoint.
+func (c *HealthRestService) Register() {
+	c.Endpoint.AddMaintenanceExemption(c.appendBaseRoute(c.route))
+
+	c.RegisterRoute(http.MethodGet, c.route+"/live", nil, c.live)
+	c.RegisterRoute(http.MethodGet, c.route+"/ready", nil, c.ready)
+}
+
+// Handles liveness requests
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *HealthRestService) live(res http.ResponseWriter, req *http.Request) {
+	HttpResponseSender.sendBody(res, req, http.StatusOK, &HealthReport{
+		Status: HealthStatusUp,
+		Time:   time.Now().UTC(),
+	})
+}
+
+// Handles readiness requests
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *HealthRestService) ready(res http.ResponseWriter, req *http.Request) {
+	report := c.CheckHealth(req.Context(), c.GetCorrelationId(req))
+	if c.Endpoint != nil && c.Endpoint.IsMaintenanceEnabled() {
+		// Cached reports are shared, so the maintenance result is added to a copy
+		report = &HealthReport{
+			Status: HealthStatusDown,
+			Time:   report.Time,
+			Checks: append(append([]*HealthCheckResult{}, report.Checks...), &HealthCheckResult{
+				Name:   "maintenance",
+				Status: HealthStatusDown,
+				Error:  "Service is under maintenance",
+			}),
+		}
+	}
+	status := http.StatusOK
+	if report.Status != HealthStatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	HttpResponseSender.sendBody(res, req, status, report)
+}
+
+// CheckHealth method are performs readiness checks in parallel and aggregates their results.
+// Results are reused for cache_timeout to protect checked components from frequent probes.
+// Concurrent probes share a single run of checks. Checks run on a context detached from
+// the probe that started them, so they are limited only by their timeouts and a cancelled
+// probe doesn't fail results of other probes.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId  (optional) transaction id to trace execution through call chain.
+//	Returns: *HealthReport the aggregated report
+func (c *HealthRestService) CheckHealth(ctx context.Context, correlationId string) *HealthReport {
+	c.lock.Lock()
+	if c.lastReport != nil && c.cacheTimeout > 0 &&
+		time.Since(c.lastReport.Time) < time.Duration(c.cacheTimeout)*time.Millisecond {
+		report := c.lastReport
+		c.lock.Unlock()
+		return report
+	}
+
+	// Concurrent probes wait for the running checks and share their results
+	if run := c.running; run != nil {
+		c.lock.Unlock()
+		<-run.done
+		return run.report
+	}
+	run := &healthCheckRun{done: make(chan struct{})}
+	c.running = run
+	checks := append(c.referencedHealthChecks(), c.checks...)
+	c.lock.Unlock()
+
+	run.report = c.performHealthChecks(detachedContext{parent: ctx}, correlationId, checks)
+
+	c.lock.Lock()
+	c.lastReport = run.report
+	c.running = nil
+	c.lock.Unlock()
+	close(run.done)
+	return run.report
+}
+
+func (c *HealthRestService) performHealthChecks(ctx context.Context, correlationId string,
+	checks []*registeredHealthCheck) *HealthReport {
+
+	results := make([]*HealthCheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check *registeredHealthCheck) {
+			defer wg.Done()
+			results[i] = c.performHealthCheck(ctx, correlationId, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := &HealthReport{
+		Status: HealthStatusUp,
+		Time:   time.Now().UTC(),
+		Checks: results,
+	}
+	for _, result := range results {
+		if result.Status != HealthStatusUp {
+			report.Status = HealthStatusDown
+			c.Logger.Warn(ctx, correlationId, "Health check %s failed: %s", result.Name, result.Error)
+		}
+	}
+	return report
+}
+
+func (c *HealthRestService) performHealthCheck(ctx context.Context, correlationId string,
+	check *registeredHealthCheck) *HealthCheckResult {
+
+	timeout := check.timeout
+	if timeout <= 0 {
+		timeout = c.checkTimeout
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				done <- errors.New(cconv.StringConverter.ToString(rec))
+			}
+		}()
+		done <- check.check.CheckHealth(checkCtx, correlationId)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-checkCtx.Done():
+		err = errors.New("health check timed out")
+	}
+
+	result := &HealthCheckResult{
+		Name:     check.name,
+		Status:   HealthStatusUp,
+		Duration: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// referencedHealthChecks creates checks for referenced IHealthCheck and crun.IOpenable components.
+func (c *HealthRestService) referencedHealthChecks() []*registeredHealthCheck {
+	checks := make([]*registeredHealthCheck, 0)
+	if c.references2 == nil {
+		return checks
+	}
+
+	for _, reference := range findReferencedComponents(c.references2) {
+		name, component := reference.name, reference.component
+		if component == nil || component == any(c) {
+			continue
+		}
+
+		if healthCheck, ok := component.(IHealthCheck); ok {
+			checks = append(checks, &registeredHealthCheck{name: name, check: healthCheck})
+		} else if openable, ok := component.(crun.IOpenable); ok {
+			checks = append(checks, &registeredHealthCheck{name: name, check: openableHealthCheck(openable)})
+		}
+	}
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].name < checks[j].name })
+	return checks
+}
+
+func openableHealthCheck(component crun.IOpenable) IHealthCheck {
+	return healthCheckFunc(func(ctx context.Context, correlationId string) error {
+		if !component.IsOpen() {
+			return errors.New("component is not open")
+		}
+		return nil
+	})
+}
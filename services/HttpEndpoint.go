@@ -1,24 +1,29 @@
 package services
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
-	"io/ioutil"
+	"io/fs"
 	"net"
 	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
 	crun "github.com/pip-services3-gox/pip-services3-commons-gox/run"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
 	crefer "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
 	cvalid "github.com/pip-services3-gox/pip-services3-commons-gox/validate"
 	ccount "github.com/pip-services3-gox/pip-services3-components-gox/count"
@@ -34,6 +39,16 @@ import (
 //
 //		- cors_headers - a comma-separated list of allowed CORS headers
 //		- cors_origins - a comma-separated list of allowed CORS origins
+//		- options:
+//			- "options.generate_correlation_id" - generate UUID correlation id when request doesn't have one (default: false)
+//			- "options.correlation_id_headers" - a comma-separated list of headers with correlation id
+//				in order of precedence (default: "correlation_id,X-Correlation-Id,X-Request-Id")
+//			- "options.request_max_size" - max size of requests and WebSocket messages in bytes (default: 1MB)
+//			- "options.websocket_ping_interval" - interval between WebSocket pings in milliseconds (default: 30000)
+//			- "options.error_format" - format of error responses: "pip" for ErrorDescription
+//				or "problem" for RFC 7807 "application/problem+json" (default: "pip")
+//			- "options.problem_type_uri" - a prefix of problem type URIs (default: "urn:pip-services:error:")
+//			- "options.maintenance_enabled" - reject requests with 503 status (default: false)
 //		- connection(s) - the connection resolver"s connections:
 //			- "connection.discovery_key" - the key to use for connection resolving in a discovery service;
 //			- "connection.protocol" - the connection"s protocol;
@@ -60,18 +75,34 @@ import (
 //		endpoint.Open(context.Background(), correlationId)
 type HttpEndpoint struct {
 	defaultConfig          *cconf.ConfigParams
+	config                 *cconf.ConfigParams
 	server                 *http.Server
 	router                 *mux.Router
 	connectionResolver     *connect.HttpConnectionResolver
 	logger                 *clog.CompositeLogger
 	counters               *ccount.CompositeCounters
-	maintenanceEnabled     bool
+	maintenanceEnabled     int32
+	maintenanceExemptions  map[string]bool
+	maintenanceLock        sync.RWMutex
 	fileMaxSize            int64
 	protocolUpgradeEnabled bool
 	uri                    string
 	registrations          []IRegisterable
 	allowedHeaders         []string
 	allowedOrigins         []string
+	generateCorrelationId  bool
+	correlationIdHeaders   []string
+	staticRoutes           []*staticFilesRoute
+	requestMaxSize         int64
+	webSocketPingInterval  time.Duration
+	webSockets             map[*WebSocketConnection]bool
+	webSocketsLock         sync.Mutex
+	problemDetails         *ProblemDetailsOptions
+}
+
+type staticFilesRoute struct {
+	route   string
+	handler http.Handler
 }
 
 const (
@@ -97,11 +128,15 @@ func NewHttpEndpoint() *HttpEndpoint {
 		"options.file_max_size", DefaultFileMaxSize,
 		"options.connect_timeout", DefaultConnectionTimeout,
 		"options.debug", "true",
+		"options.generate_correlation_id", false,
+		"options.correlation_id_headers", strings.Join(DefaultCorrelationIdHeaders, ","),
+		"options.websocket_ping_interval", int64(DefaultWebSocketPingInterval/time.Millisecond),
+		"options.error_format", ErrorFormatPip,
+		"options.problem_type_uri", DefaultProblemTypeUri,
 	)
 	c.connectionResolver = connect.NewHttpConnectionResolver()
 	c.logger = clog.NewCompositeLogger()
 	c.counters = ccount.NewCompositeCounters()
-	c.maintenanceEnabled = false
 	c.fileMaxSize = DefaultFileMaxSize
 	c.protocolUpgradeEnabled = false
 	c.registrations = make([]IRegisterable, 0)
@@ -116,6 +151,11 @@ func NewHttpEndpoint() *HttpEndpoint {
 		//"access_token",
 	}
 	c.allowedOrigins = make([]string, 0)
+	c.generateCorrelationId = false
+	c.correlationIdHeaders = DefaultCorrelationIdHeaders
+	c.requestMaxSize = DefaultRequestMaxSize
+	c.webSocketPingInterval = DefaultWebSocketPingInterval
+	c.webSockets = make(map[*WebSocketConnection]bool)
 	return &c
 }
 
@@ -130,16 +170,43 @@ func NewHttpEndpoint() *HttpEndpoint {
 //			- "credential.ssl_key_file" - SSL func (c *HttpEndpoint )key in PEM
 //			- "credential.ssl_crt_file" - SSL certificate in PEM
 //			- "credential.ssl_ca_file" - Certificate authority (root certificate) in PEM
+//			- "options.generate_correlation_id" - generate correlation id when request doesn't have one
+//			- "options.correlation_id_headers" - headers with correlation id in order of precedence
+//			- "options.request_max_size" - max size of requests and WebSocket messages in bytes
+//			- "options.websocket_ping_interval" - interval between WebSocket pings in milliseconds
+//			- "options.error_format" - format of error responses: "pip" or "problem"
+//			- "options.problem_type_uri" - a prefix of problem type URIs
 //	Parameters:
 //		- ctx context.Context
 //		- config    configuration parameters, containing a "connection(s)" section.
 func (c *HttpEndpoint) Configure(ctx context.Context, config *cconf.ConfigParams) {
 	config = config.SetDefaults(c.defaultConfig)
+	c.config = config
 	c.connectionResolver.Configure(ctx, config)
 
-	c.maintenanceEnabled = config.GetAsBooleanWithDefault("options.maintenance_enabled", c.maintenanceEnabled)
+	c.SetMaintenanceEnabled(config.GetAsBooleanWithDefault("options.maintenance_enabled", c.IsMaintenanceEnabled()))
 	c.fileMaxSize = config.GetAsLongWithDefault("options.file_max_size", c.fileMaxSize)
 	c.protocolUpgradeEnabled = config.GetAsBooleanWithDefault("options.protocol_upgrade_enabled", c.protocolUpgradeEnabled)
+	c.generateCorrelationId = config.GetAsBooleanWithDefault("options.generate_correlation_id", c.generateCorrelationId)
+	c.requestMaxSize = config.GetAsLongWithDefault("options.request_max_size", c.requestMaxSize)
+	c.webSocketPingInterval = time.Duration(config.GetAsLongWithDefault("options.websocket_ping_interval",
+		int64(c.webSocketPingInterval/time.Millisecond))) * time.Millisecond
+	c.problemDetails = newProblemDetailsOptions(
+		config.GetAsString("options.error_format"),
+		config.GetAsString("options.problem_type_uri"),
+	)
+
+	correlationIdHeaders := make([]string, 0)
+	for _, header := range strings.Split(config.GetAsStringWithDefault("options.correlation_id_headers", ""), ",") {
+		header = strings.TrimSpace(header)
+		if header != "" {
+			correlationIdHeaders = append(correlationIdHeaders, header)
+			c.AddCorsHeader(header, "")
+		}
+	}
+	if len(correlationIdHeaders) > 0 {
+		c.correlationIdHeaders = correlationIdHeaders
+	}
 
 	headers := strings.Split(config.GetAsStringWithDefault("cors_headers", ""), ",")
 	if len(headers) > 0 {
@@ -175,6 +242,37 @@ func (c *HttpEndpoint) IsOpen() bool {
 	return c.server != nil
 }
 
+// GetAddress method returns the address the server listens on, i.e. "0.0.0.0:8080".
+//	Returns: string the bind address or empty string when the endpoint is not open
+func (c *HttpEndpoint) GetAddress() string {
+	if c.server == nil {
+		return ""
+	}
+	return c.server.Addr
+}
+
+// GetUri method returns the resolved URI of the opened endpoint.
+//	Returns: string the endpoint URI or empty string when the endpoint is not open
+func (c *HttpEndpoint) GetUri() string {
+	return c.uri
+}
+
+// GetRouteCount method returns the number of registered routes, including static file routes.
+//	Returns: int the number of routes or 0 when the endpoint is not open
+func (c *HttpEndpoint) GetRouteCount() int {
+	if c.server == nil || c.router == nil {
+		return 0
+	}
+	count := 0
+	_ = c.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		if route.GetHandler() != nil {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
 // Open a connection using the parameters resolved by the referenced connection
 // resolver and creates a REST server (service) using the set options and parameters.
 //	Parameters:
@@ -211,8 +309,24 @@ func (c *HttpEndpoint) Open(ctx context.Context, correlationId string) error {
 		"PATCH",
 	})
 	allowedHeaders := handlers.AllowedHeaders(c.allowedHeaders)
-	c.server.Handler = handlers.CORS(allowedOrigins, allowedMethods, allowedHeaders)(c.router)
+	// Browsers let scripts read only exposed response headers
+	exposedHeaders := handlers.ExposedHeaders([]string{
+		string(PipCorrelationId),
+		TraceParentHeader,
+		TraceStateHeader,
+		TotalCountHeader,
+		LinkHeader,
+		DeprecationHeader,
+		SunsetHeader,
+		ResponseCacheHeader,
+		IdempotentReplayedHeader,
+		"Retry-After",
+	})
+	c.server.Handler = handlers.CORS(allowedOrigins, allowedMethods, allowedHeaders, exposedHeaders)(c.router)
 
+	c.router.Use(c.correlationId)
+	c.router.Use(c.traceContext)
+	c.router.Use(c.errorFormat)
 	c.router.Use(c.noCache)
 	c.router.Use(c.doMaintenance)
 
@@ -251,6 +365,46 @@ func (c *HttpEndpoint) Open(ctx context.Context, correlationId string) error {
 	return regErr
 }
 
+// correlationId resolves the request correlation id, generates a new one if it is enabled,
+// stores it in the request context and echoes it back in the response headers
+func (c *HttpEndpoint) correlationId(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationId := getRequestCorrelationId(r, c.correlationIdHeaders)
+		if correlationId == "" && c.generateCorrelationId {
+			correlationId = uuid.NewString()
+		}
+		if correlationId != "" {
+			r = r.WithContext(context.WithValue(r.Context(), PipCorrelationId, correlationId))
+			w.Header().Set(string(PipCorrelationId), correlationId)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// traceContext extracts W3C trace context from "traceparent" and "tracestate" headers
+// and stores it in the request context. When headers are missing it starts a new trace.
+func (c *HttpEndpoint) traceContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceContext, ok := ParseTraceContext(r.Header.Get(TraceParentHeader), r.Header.Get(TraceStateHeader))
+		if !ok {
+			traceContext = NewTraceContext()
+		}
+		r = r.WithContext(WithTraceContext(r.Context(), traceContext))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// errorFormat stores problem details options in the request context,
+// so errors are sent in RFC 7807 format when it is enabled
+func (c *HttpEndpoint) errorFormat(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.problemDetails != nil {
+			r = r.WithContext(WithProblemDetails(r.Context(), c.problemDetails))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // noCache prevents IE from caching REST requests
 func (c *HttpEndpoint) noCache(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -261,21 +415,68 @@ func (c *HttpEndpoint) noCache(next http.Handler) http.Handler {
 	})
 }
 
-// doMaintenance returns maintenance error code
+// doMaintenance rejects requests with 503 status while maintenance mode is enabled.
+// Requests to exempted routes, i.e. admin routes that turn the mode off, are served as usual.
 func (c *HttpEndpoint) doMaintenance(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Make this more sophisticated
-		if c.maintenanceEnabled {
-			w.Header().Add("Retry-After", "3600")
-			jsonStr, _ := cconv.JsonConverter.ToJson(503)
-			_, _ = w.Write([]byte(jsonStr))
-			next.ServeHTTP(w, r)
-		} else {
+		if !c.IsMaintenanceEnabled() || c.isMaintenanceExempted(r.URL.Path) {
 			next.ServeHTTP(w, r)
+			return
 		}
+		w.Header().Add("Retry-After", "3600")
+		HttpResponseSender.SendError(w, r, cerr.NewInvalidStateError(c.GetCorrelationId(r), "MAINTENANCE",
+			"Service is under maintenance").WithStatus(http.StatusServiceUnavailable))
 	})
 }
 
+func (c *HttpEndpoint) isMaintenanceExempted(path string) bool {
+	c.maintenanceLock.RLock()
+	defer c.maintenanceLock.RUnlock()
+	for route := range c.maintenanceExemptions {
+		if path == route || strings.HasPrefix(path, strings.TrimSuffix(route, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetConfig method returns the effective configuration of this endpoint.
+//	Returns: *cconf.ConfigParams configuration parameters with defaults, nil when the endpoint was not configured
+func (c *HttpEndpoint) GetConfig() *cconf.ConfigParams {
+	return c.config
+}
+
+// IsMaintenanceEnabled method checks if maintenance mode is enabled.
+//	Returns: bool true when requests are rejected with 503 status
+func (c *HttpEndpoint) IsMaintenanceEnabled() bool {
+	return atomic.LoadInt32(&c.maintenanceEnabled) != 0
+}
+
+// SetMaintenanceEnabled method enables or disables maintenance mode at runtime.
+//	Parameters:
+//		- enabled   true to reject requests with 503 status
+func (c *HttpEndpoint) SetMaintenanceEnabled(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&c.maintenanceEnabled, value)
+}
+
+// AddMaintenanceExemption method adds a route prefix that is served while maintenance mode is enabled.
+// Services add their routes every time they are registered, so repeated routes are kept once.
+//	Parameters:
+//		- route   a route prefix, i.e. "/admin"
+func (c *HttpEndpoint) AddMaintenanceExemption(route string) {
+	route = c.fixRoute(route)
+	c.maintenanceLock.Lock()
+	defer c.maintenanceLock.Unlock()
+	if c.maintenanceExemptions == nil {
+		c.maintenanceExemptions = make(map[string]bool)
+	}
+	c.maintenanceExemptions[route] = true
+}
+
 // Close method are closes this endpoint and the REST server (service) that was opened earlier.
 //	Parameters:
 //		- ctx context.Context
@@ -283,6 +484,9 @@ func (c *HttpEndpoint) doMaintenance(next http.Handler) http.Handler {
 //	Returns: error an error if one is raised.
 func (c *HttpEndpoint) Close(ctx context.Context, correlationId string) error {
 	if c.server != nil {
+		// Hijacked WebSocket connections are not closed by the server shutdown
+		c.closeWebSockets()
+
 		// Attempt a graceful shutdown
 		_ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 		defer cancel()
@@ -326,9 +530,24 @@ func (c *HttpEndpoint) Unregister(registration IRegisterable) {
 }
 
 func (c *HttpEndpoint) performRegistrations() {
+	c.staticRoutes = make([]*staticFilesRoute, 0)
 	for _, registration := range c.registrations {
 		registration.Register()
 	}
+
+	// Static files are registered last with longer prefixes first,
+	// so they don't shadow other routes
+	sort.SliceStable(c.staticRoutes, func(i, j int) bool {
+		return len(c.staticRoutes[i].route) > len(c.staticRoutes[j].route)
+	})
+	for _, staticRoute := range c.staticRoutes {
+		prefix := staticRoute.route
+		if prefix == "" {
+			prefix = "/"
+		}
+		c.router.PathPrefix(prefix).Handler(staticRoute.handler).
+			Methods(http.MethodGet, http.MethodHead)
+	}
 }
 
 func (c *HttpEndpoint) fixRoute(route string) string {
@@ -343,11 +562,7 @@ func (c *HttpEndpoint) fixRoute(route string) string {
 //		- req *http.Request  request
 //	Returns: string correlation_id or empty string
 func (c *HttpEndpoint) GetCorrelationId(req *http.Request) string {
-	correlationId := req.URL.Query().Get("correlation_id")
-	if correlationId == "" {
-		correlationId = req.Header.Get("correlation_id")
-	}
-	return correlationId
+	return getRequestCorrelationId(req, c.correlationIdHeaders)
 }
 
 // RegisterRoute method are registers an action in this objects REST server (service)
@@ -360,6 +575,54 @@ func (c *HttpEndpoint) GetCorrelationId(req *http.Request) string {
 func (c *HttpEndpoint) RegisterRoute(method string, route string, schema *cvalid.Schema,
 	action http.HandlerFunc) {
 
+	c.RegisterVersionedRoute(method, route, schema, nil, action)
+}
+
+// RegisterVersionedRoute method are registers an action of an API version in this objects REST server (service).
+// Versions selected by headers can share the same route, the request is served by the matching version.
+// Responses of deprecated versions have "Deprecation" and "Sunset" headers.
+//	Parameters:
+//		- method   string     the HTTP method of the route.
+//		- route    string     the route to register in this object"s REST server (service).
+//		- schema   *cvalid.Schema     the schema to use for parameter validation.
+//		- version  *ApiVersion     (optional) the API version of the route.
+//		- action   http.HandlerFunc     the action to perform at the given route.
+func (c *HttpEndpoint) RegisterVersionedRoute(method string, route string, schema *cvalid.Schema,
+	version *ApiVersion, action http.HandlerFunc) {
+
+	c.registerRoute(method, route, schema, nil, version, nil, action)
+}
+
+// RegisterParamsRoute method are registers an action of an API version with an object schema of its parameters.
+// Query parameters and route variables are converted to the types of the schema properties
+// before validation, i.e. "123" to int64 for TypeCode.Long. Array properties receive
+// all values of repeated keys or a comma list (?id=1&id=2 or ?id=1,2).
+// Parameters of routes registered with RegisterRoute or RegisterVersionedRoute are converted
+// to the types the schema expects as well, properties of the object schema only save validation passes.
+//	Parameters:
+//		- method   string     the HTTP method of the route.
+//		- route    string     the route to register in this object"s REST server (service).
+//		- schema   *cvalid.ObjectSchema     the schema of parameters to convert and validate them.
+//		- version  *ApiVersion     (optional) the API version of the route.
+//		- action   http.HandlerFunc     the action to perform at the given route.
+func (c *HttpEndpoint) RegisterParamsRoute(method string, route string, schema *cvalid.ObjectSchema,
+	version *ApiVersion, action http.HandlerFunc) {
+
+	if schema == nil {
+		c.registerRoute(method, route, nil, nil, version, nil, action)
+		return
+	}
+	c.registerRoute(method, route, schema.Schema, schema.Properties(), version, nil, action)
+}
+
+// registerRoute registers an action of an API version with a function that prepares
+// requests of the route before validation, i.e. to set per-service options in the context.
+// Parameters are converted to the types of the properties when they are set
+// and to the types reported by validation of the schema otherwise.
+func (c *HttpEndpoint) registerRoute(method string, route string, schema *cvalid.Schema,
+	properties []*cvalid.PropertySchema, version *ApiVersion,
+	prepare func(req *http.Request) *http.Request, action http.HandlerFunc) {
+
 	method = strings.ToLower(method)
 	if method == "del" {
 		method = "delete"
@@ -376,40 +639,132 @@ func (c *HttpEndpoint) RegisterRoute(method string, route string, schema *cvalid
 				c.logger.Error(r.Context(), c.GetCorrelationId(r), err, "http handler panics with error")
 			}
 		}()
+		if prepare != nil {
+			r = prepare(r)
+		}
+		// The body is read and decoded once and shared by validation and handlers
+		r = withRequestBodyCache(r)
 		//  Perform validation
 		if schema != nil {
 			var params = make(map[string]any, 0)
-			for k, v := range r.URL.Query() {
-				params[k] = v[0]
-			}
+			putRequestParams(params, r, properties)
 
-			for k, v := range mux.Vars(r) {
-				params[k] = v
-			}
-
-			// Make copy of request
-			bodyBuf, bodyErr := ioutil.ReadAll(r.Body)
-			if bodyErr != nil {
+			if _, bodyErr := GetRequestBody(r); bodyErr != nil {
 				HttpResponseSender.SendError(w, r, bodyErr)
 				return
 			}
-			_ = r.Body.Close()
-			r.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBuf))
-			//-------------------------
-			var body any
-			_ = json.Unmarshal(bodyBuf, &body)
+			body, _ := GetRequestBodyValue(r)
 			params["body"] = body
 
+			// Send every failed field with its location, so clients can highlight them
 			correlationId := c.GetCorrelationId(r)
-			err := schema.ValidateAndReturnError(correlationId, params, false)
+			results := validateRequestParams(params, r, schema)
+			err := cvalid.NewValidationErrorFromResults(correlationId, results, false)
 			if err != nil {
+				err.WithDetails(ValidationResultsDetail, NewFieldValidationErrors(r, results))
 				HttpResponseSender.SendError(w, r, err)
 				return
 			}
+			// Coerced parameters are available to handlers by GetRequestParams
+			r = withRequestParams(r, params)
+		}
+		if version != nil {
+			version.setHeaders(w)
 		}
 		action(w, r)
 	})
-	c.router.Handle(route, actionCurl).Methods(strings.ToUpper(method))
+	muxRoute := c.router.Handle(route, actionCurl).Methods(strings.ToUpper(method))
+	if version != nil && !version.IsSelectedByPath() {
+		muxRoute.MatcherFunc(func(r *http.Request, _ *mux.RouteMatch) bool {
+			return version.Matches(r)
+		})
+	}
+}
+
+// RegisterStaticFiles method are registers a route that serves static files from the given file system.
+// Files are served with detected content types and caching headers. Static routes are matched
+// after all other routes, so a single-page app can be hosted next to an API.
+//	Parameters:
+//		- route      string     the route prefix to serve files at.
+//		- fileSystem fs.FS      the file system with static files, i.e. os.DirFS or embed.FS.
+//		- options    *StaticFilesOptions (optional) options to serve files, default options are used for nil.
+func (c *HttpEndpoint) RegisterStaticFiles(route string, fileSystem fs.FS, options *StaticFilesOptions) {
+	route = strings.TrimSuffix(c.fixRoute(route), "/")
+	c.staticRoutes = append(c.staticRoutes, &staticFilesRoute{
+		route:   route,
+		handler: newStaticFilesHandler(route, fileSystem, options),
+	})
+}
+
+// RegisterWebSocketRoute method are registers a WebSocket route in this objects REST server (service).
+// The authorization interceptor is called before the connection is upgraded.
+// Size of received messages is limited by "options.request_max_size" and connections
+// are kept alive with pings. The connection is closed when the handler returns or
+// when the endpoint is closed.
+//	Parameters:
+//		- route      string     the route to register in this object"s REST server (service).
+//		- authorize  (optional) the authorization interceptor
+//		- handler    the handler of the opened connection.
+func (c *HttpEndpoint) RegisterWebSocketRoute(route string,
+	authorize func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc),
+	handler func(req *http.Request, conn *WebSocketConnection)) {
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: c.checkWebSocketOrigin,
+		Error: func(w http.ResponseWriter, r *http.Request, status int, reason error) {
+			HttpResponseSender.SendError(w, r, cerr.NewBadRequestError(
+				c.GetCorrelationId(r), "WEBSOCKET_UPGRADE_FAILED", reason.Error()).WithStatus(status))
+		},
+	}
+
+	c.RegisterRouteWithAuth(http.MethodGet, route, nil, authorize, func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			c.logger.Debug(r.Context(), c.GetCorrelationId(r), "Failed to upgrade connection to WebSocket: %s", err.Error())
+			return
+		}
+
+		wsConn := NewWebSocketConnection(conn, c.requestMaxSize, c.webSocketPingInterval)
+		c.webSocketsLock.Lock()
+		c.webSockets[wsConn] = true
+		c.webSocketsLock.Unlock()
+
+		defer func() {
+			c.webSocketsLock.Lock()
+			delete(c.webSockets, wsConn)
+			c.webSocketsLock.Unlock()
+			_ = wsConn.Close()
+		}()
+
+		handler(r, wsConn)
+	})
+}
+
+// checkWebSocketOrigin allows WebSocket connections from the configured CORS origins
+// or from the same host
+func (c *HttpEndpoint) checkWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowedOrigin := range c.allowedOrigins {
+		if allowedOrigin == "*" || strings.EqualFold(allowedOrigin, origin) {
+			return true
+		}
+	}
+	originUrl, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(originUrl.Host, r.Host)
+}
+
+func (c *HttpEndpoint) closeWebSockets() {
+	c.webSocketsLock.Lock()
+	webSockets := c.webSockets
+	c.webSockets = make(map[*WebSocketConnection]bool)
+	c.webSocketsLock.Unlock()
+
+	for wsConn := range webSockets {
+		_ = wsConn.CloseWithReason(websocket.CloseGoingAway, "Server is shutting down")
+	}
 }
 
 // RegisterRouteWithAuth method are registers an action with authorization in this objects REST server (service)
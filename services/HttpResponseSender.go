@@ -1,44 +1,109 @@
 package services
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
 
 	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
 )
 
+const (
+	NdjsonContentType = "application/x-ndjson"
+	JsonContentType   = "application/json"
+
+	// StreamErrorTrailer is a trailer with an error that interrupted a stream.
+	StreamErrorTrailer = "X-Stream-Error"
+
+	streamFlushSize = 100
+)
+
 // HttpResponseSender helper class that handles HTTP-based responses.
 var HttpResponseSender = _THttpResponseSender{}
 
 type _THttpResponseSender struct {
 }
 
-// SendError sends error serialized as ErrorDescription object
+// SendError sends error serialized as ErrorDescription object in a format accepted by the client
 // and appropriate HTTP status code.
 // If status code is not defined, it uses 500 status code.
+// When problem details are enabled in the request context (see WithProblemDetails),
+// the error is sent in "application/problem+json" format (RFC 7807).
 //
 //	Parameters:
 //		- req  *http.Request     a HTTP request object.
 //		- res  http.ResponseWriter     a HTTP response object.
 //		- err  error     an error object to be sent.
 func (c *_THttpResponseSender) SendError(res http.ResponseWriter, req *http.Request, err error) {
-	appErr := cerr.ErrorDescriptionFactory.Create(err)
-	res.Header().Add("Content-Type", "application/json")
+	if req != nil {
+		if options, ok := GetProblemDetailsOptions(req.Context()); ok {
+			problem := NewProblemDetailsFromError(err, options.TypeUri)
+			data, _ := json.Marshal(problem)
+			res.Header().Add("Content-Type", ProblemJsonContentType)
+			res.WriteHeader(problem.Status)
+			_, _ = res.Write(data)
+			return
+		}
+	}
+
+	appErr := withFieldValidationErrors(cerr.ErrorDescriptionFactory.Create(err))
+	serializer := Serializers.ForRequest(req)
+	data, serializeErr := serializer.Serialize(appErr)
+	res.Header().Add("Content-Type", serializer.ContentType())
 	res.WriteHeader(appErr.Status)
-	jsonObjStr, jsonErr := cconv.JsonConverter.ToJson(appErr)
-	if jsonErr == nil {
-		_, _ = io.WriteString(res, jsonObjStr)
+	if serializeErr == nil {
+		_, _ = res.Write(data)
+	}
+}
+
+// sendBody serializes the result in a format negotiated by "Accept" header of the request.
+// Serialization errors are sent as ErrorDescription with 500 status code.
+func (c *_THttpResponseSender) sendBody(res http.ResponseWriter, req *http.Request, status int, result any) {
+	// Paging headers and projection are opt-in by the request context or RestService options.
+	// Paging headers are set before projection, which converts data pages to maps
+	if req != nil && IsPagingHeadersEnabled(req.Context()) {
+		setPagingHeaders(res, req, result)
 	}
+	if req != nil && IsResponseProjectionEnabled(req.Context()) {
+		projected, err := ApplyProjection(result, getRequestProjectionParams(req))
+		if err != nil {
+			HttpResponseSender.SendError(res, req, cerr.NewInternalError("", "PROJECTION_FAILED",
+				"Failed to apply projection to response").WithCause(err))
+			return
+		}
+		result = projected
+	}
+
+	serializer := Serializers.ForRequest(req)
+	data, err := serializer.Serialize(result)
+	if err != nil {
+		HttpResponseSender.SendError(res, req, cerr.NewInternalError("", "SERIALIZATION_FAILED",
+			"Failed to serialize response").WithCause(err))
+		return
+	}
+	res.Header().Add("Content-Type", serializer.ContentType())
+	res.Header().Add("Vary", "Accept")
+	res.WriteHeader(status)
+	_, _ = res.Write(data)
 }
 
-// SendResult sends result as JSON object.
+// SendResult sends result serialized in a format accepted by the client (JSON by default).
 // That function call be called directly or passed
 // as a parameter to business logic components.
 // If object is not nil it returns 200 status code.
 // For nil results it returns 204 status code.
 // If error occur it sends ErrorDescription with approproate status code.
+// When projection is enabled in the request context (see WithResponseProjection),
+// only fields listed in "fields" parameter are sent. When paging headers are enabled
+// (see WithPagingHeaders), data pages are sent with "X-Total-Count" and "Link" headers.
 //
 //	Parameters:
 //		- req  *http.Request     a HTTP request object.
@@ -54,11 +119,7 @@ func (c *_THttpResponseSender) SendResult(res http.ResponseWriter, req *http.Req
 		res.Header().Add("Content-Type", "application/json")
 		res.WriteHeader(204)
 	} else {
-		res.Header().Add("Content-Type", "application/json")
-		jsonObjStr, jsonErr := cconv.JsonConverter.ToJson(result)
-		if jsonErr == nil {
-			_, _ = io.WriteString(res, jsonObjStr)
-		}
+		HttpResponseSender.sendBody(res, req, 200, result)
 	}
 }
 
@@ -77,7 +138,7 @@ func (c *_THttpResponseSender) SendEmptyResult(res http.ResponseWriter, req *htt
 	res.WriteHeader(204)
 }
 
-// SendCreatedResult are sends newly created object as JSON.
+// SendCreatedResult are sends newly created object serialized in a format accepted by the client.
 // That function call be called directly or passed
 // as a parameter to business logic components.
 // If object is not nil it returns 201 status code.
@@ -96,16 +157,11 @@ func (c *_THttpResponseSender) SendCreatedResult(res http.ResponseWriter, req *h
 		res.Header().Add("Content-Type", "application/json")
 		res.WriteHeader(204)
 	} else {
-		res.Header().Add("Content-Type", "application/json")
-		res.WriteHeader(201)
-		jsonObjStr, jsonErr := cconv.JsonConverter.ToJson(result)
-		if jsonErr == nil {
-			_, _ = io.WriteString(res, jsonObjStr)
-		}
+		HttpResponseSender.sendBody(res, req, 201, result)
 	}
 }
 
-// SendDeletedResult are sends deleted object as JSON.
+// SendDeletedResult are sends deleted object serialized in a format accepted by the client.
 // That function call be called directly or passed
 // as a parameter to business logic components.
 // If object is not nil it returns 200 status code.
@@ -124,11 +180,248 @@ func (c *_THttpResponseSender) SendDeletedResult(res http.ResponseWriter, req *h
 		res.Header().Add("Content-Type", "application/json")
 		res.WriteHeader(204)
 	} else {
-		res.Header().Add("Content-Type", "application/json")
+		HttpResponseSender.sendBody(res, req, 200, result)
+	}
+}
+
+// SendEventStream sends events from the channel as Server-Sent Events ("text/event-stream").
+// Each event is flushed to the client right away. When heartbeat is set, comments are sent
+// periodically to keep the connection open through proxies. The method blocks until
+// the channel is closed or the client disconnects. Producers must close the channel and
+// should stop on req.Context() cancellation, remaining events are drained in background.
+//
+//	Parameters:
+//		- req  *http.Request     a HTTP request object.
+//		- res  http.ResponseWriter     a HTTP response object.
+//		- events <-chan *ServerSentEvent     a channel with events to be sent.
+//		- heartbeat time.Duration     an interval between heartbeat comments, 0 disables heartbeats.
+func (c *_THttpResponseSender) SendEventStream(res http.ResponseWriter, req *http.Request,
+	events <-chan *ServerSentEvent, heartbeat time.Duration) {
+
+	closed := false
+	defer func() {
+		if !closed {
+			go drainChannel(events)
+		}
+	}()
+
+	flusher, ok := res.(http.Flusher)
+	if !ok {
+		HttpResponseSender.SendError(res, req, cerr.NewUnsupportedError("", "STREAMING_NOT_SUPPORTED",
+			"Response doesn't support streaming"))
+		return
+	}
+
+	res.Header().Set("Content-Type", EventStreamContentType)
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(200)
+	flusher.Flush()
+
+	var ticks <-chan time.Time
+	if heartbeat > 0 {
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				closed = true
+				return
+			}
+			if event == nil {
+				continue
+			}
+			if _, err := event.WriteTo(res); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticks:
+			if _, err := io.WriteString(res, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// SendStream sends items from the channel without buffering the whole result.
+// Items are written as newline-delimited JSON ("application/x-ndjson") if the client accepts it,
+// otherwise as a chunked JSON array. Streams are always encoded as JSON, serializers
+// registered in Serializers are not used, so requests that accept neither JSON nor NDJSON
+// are rejected with 406 status code. Data is flushed to the client every 100 items
+// and whenever the channel has no ready items.
+// An error received from the channel ends the stream. If nothing was sent yet, it is sent
+// as ErrorDescription with appropriate status code, otherwise it is sent in "X-Stream-Error" trailer.
+// The method blocks until the channel is closed or the client disconnects.
+// Producers must close the channel when they are done and should stop on req.Context() cancellation.
+// When the stream ends early, remaining items are drained in background to unblock producers,
+// so a producer that never closes the channel leaks the draining goroutine.
+//
+//	Parameters:
+//		- req  *http.Request     a HTTP request object.
+//		- res  http.ResponseWriter     a HTTP response object.
+//		- items <-chan any     a channel with items or an error to be sent.
+//
+//	Example:
+//		items := make(chan any)
+//		go func() {
+//			defer close(items)
+//			for _, item := range data {
+//				select {
+//				case items <- item:
+//				case <-req.Context().Done():
+//					return
+//				}
+//			}
+//		}()
+//		HttpResponseSender.SendStream(res, req, items)
+func (c *_THttpResponseSender) SendStream(res http.ResponseWriter, req *http.Request, items <-chan any) {
+	accept := req.Header.Get("Accept")
+	ndjson := strings.Contains(accept, NdjsonContentType)
+
+	closed := false
+	defer func() {
+		if !closed {
+			go drainChannel(items)
+		}
+	}()
+
+	if !acceptsJsonStream(accept) {
+		HttpResponseSender.SendError(res, req, cerr.NewBadRequestError("", "NOT_ACCEPTABLE",
+			"Streams are sent only as JSON or newline-delimited JSON").WithStatus(http.StatusNotAcceptable))
+		return
+	}
+
+	var writer *bufio.Writer
+	// Items are encoded before they are written, so failed items don't break the output
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	count := 0
+	pending := 0
+
+	flush := func() {
+		if writer != nil && pending > 0 {
+			_ = writer.Flush()
+			if flusher, ok := res.(http.Flusher); ok {
+				flusher.Flush()
+			}
+			pending = 0
+		}
+	}
+
+	start := func() {
+		if writer != nil {
+			return
+		}
+		res.Header().Set("Trailer", StreamErrorTrailer)
+		if ndjson {
+			res.Header().Set("Content-Type", NdjsonContentType)
+		} else {
+			res.Header().Set("Content-Type", JsonContentType)
+		}
 		res.WriteHeader(200)
-		jsonObjStr, jsonErr := cconv.JsonConverter.ToJson(result)
-		if jsonErr == nil {
-			_, _ = io.WriteString(res, jsonObjStr)
+		writer = bufio.NewWriter(res)
+		if !ndjson {
+			_, _ = writer.WriteString("[")
+		}
+	}
+
+	end := func(err error) {
+		if err != nil && writer == nil {
+			HttpResponseSender.SendError(res, req, err)
+			return
+		}
+		start()
+		if !ndjson {
+			_, _ = writer.WriteString("]")
+		}
+		_ = writer.Flush()
+		if err != nil {
+			appErr := cerr.ErrorDescriptionFactory.Create(err)
+			jsonObjStr, _ := cconv.JsonConverter.ToJson(appErr)
+			res.Header().Set(StreamErrorTrailer, jsonObjStr)
 		}
 	}
+
+	for {
+		var item any
+		var ok bool
+
+		// Flush buffered items while waiting for the next ones
+		select {
+		case item, ok = <-items:
+		default:
+			flush()
+			select {
+			case item, ok = <-items:
+			case <-req.Context().Done():
+				return
+			}
+		}
+
+		if !ok {
+			closed = true
+			end(nil)
+			return
+		}
+		if err, isErr := item.(error); isErr {
+			end(err)
+			return
+		}
+
+		buffer.Reset()
+		if err := encoder.Encode(item); err != nil {
+			end(err)
+			return
+		}
+		start()
+		if !ndjson && count > 0 {
+			_, _ = writer.WriteString(",")
+		}
+		_, _ = writer.Write(buffer.Bytes())
+		count++
+		pending++
+		if pending >= streamFlushSize {
+			flush()
+		}
+	}
+}
+
+// acceptsJsonStream checks if a value of "Accept" header allows JSON or NDJSON responses.
+// Empty header accepts any media type.
+func acceptsJsonStream(accept string) bool {
+	if strings.TrimSpace(accept) == "" {
+		return true
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if quality, err := strconv.ParseFloat(q, 64); err != nil || quality <= 0 {
+				continue
+			}
+		}
+		switch {
+		case mediaType == "*/*", mediaType == "application/*",
+			mediaType == JsonContentType, mediaType == NdjsonContentType,
+			strings.HasSuffix(mediaType, "+json"):
+			return true
+		}
+	}
+	return false
+}
+
+// drainChannel reads the channel until it is closed to unblock producers of abandoned streams.
+func drainChannel[T any](items <-chan T) {
+	for range items {
+	}
 }
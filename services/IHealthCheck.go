@@ -0,0 +1,12 @@
+package services
+
+import "context"
+
+// IHealthCheck interface for components that report their health to HealthRestService.
+// The health service finds referenced components that implement it and includes them in readiness checks.
+type IHealthCheck interface {
+
+	// CheckHealth checks if the component is able to serve requests.
+	// It returns an error that describes the problem when the component is unhealthy.
+	CheckHealth(ctx context.Context, correlationId string) error
+}
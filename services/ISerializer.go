@@ -0,0 +1,14 @@
+package services
+
+// ISerializer is interface for components that encode and decode HTTP bodies
+// in a specific media type, i.e. JSON or CBOR.
+type ISerializer interface {
+	// ContentType returns the media type produced and accepted by the serializer.
+	ContentType() string
+
+	// Serialize encodes the value.
+	Serialize(value any) ([]byte, error)
+
+	// Deserialize decodes the data into the value the target points to.
+	Deserialize(data []byte, target any) error
+}
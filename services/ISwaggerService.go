@@ -6,3 +6,11 @@ type ISwaggerService interface {
 	// RegisterOpenApiSpec Perform required Swagger registration steps.
 	RegisterOpenApiSpec(baseRoute string, swaggerRoute string)
 }
+
+// ISwaggerContentService Interface of swagger services that also collect contents of OpenAPI documents,
+// i.e. to merge them. RestService passes documents to swagger services that implement it.
+type ISwaggerContentService interface {
+
+	// RegisterOpenApiSpecContent Registers content of OpenAPI document served at the swagger route.
+	RegisterOpenApiSpecContent(baseRoute string, swaggerRoute string, content string)
+}
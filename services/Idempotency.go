@@ -0,0 +1,188 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	ccache "github.com/pip-services3-gox/pip-services3-components-gox/cache"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	DefaultIdempotencyTimeout = 24 * 60 * 60 * 1000
+)
+
+// IdempotentResponse is a response stored for an idempotency key.
+type IdempotentResponse struct {
+	// RequestHash is a hash of the request method, path and body the key was first used with.
+	RequestHash string `json:"request_hash"`
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+	// Header contains response headers.
+	Header http.Header `json:"header"`
+	// Body is the response body.
+	Body []byte `json:"body"`
+}
+
+// IIdempotencyStore stores responses of requests with idempotency keys.
+type IIdempotencyStore interface {
+	// Retrieve gets a stored response by its key or nil if it was not stored or expired.
+	Retrieve(ctx context.Context, correlationId string, key string) (*IdempotentResponse, error)
+
+	// Store saves a response by its key for the timeout in milliseconds.
+	Store(ctx context.Context, correlationId string, key string, response *IdempotentResponse, timeout int64) error
+}
+
+// CacheIdempotencyStore is an idempotency store that keeps responses in a components ICache,
+// i.e. a distributed cache shared by service instances.
+type CacheIdempotencyStore struct {
+	cache ccache.ICache[*IdempotentResponse]
+}
+
+// NewCacheIdempotencyStore creates a new idempotency store over the cache.
+//
+//	Parameters:
+//		- cache ccache.ICache[*IdempotentResponse] a cache to keep responses
+//	Returns: *CacheIdempotencyStore
+func NewCacheIdempotencyStore(cache ccache.ICache[*IdempotentResponse]) *CacheIdempotencyStore {
+	return &CacheIdempotencyStore{cache: cache}
+}
+
+// NewMemoryIdempotencyStore creates a new idempotency store that keeps responses in memory.
+//
+//	Returns: *CacheIdempotencyStore
+func NewMemoryIdempotencyStore() *CacheIdempotencyStore {
+	return NewCacheIdempotencyStore(ccache.NewMemoryCache[*IdempotentResponse]())
+}
+
+// Retrieve gets a stored response by its key or nil if it was not stored or expired.
+func (c *CacheIdempotencyStore) Retrieve(ctx context.Context, correlationId string, key string) (*IdempotentResponse, error) {
+	return c.cache.Retrieve(ctx, correlationId, key)
+}
+
+// Store saves a response by its key for the timeout in milliseconds.
+func (c *CacheIdempotencyStore) Store(ctx context.Context, correlationId string, key string,
+	response *IdempotentResponse, timeout int64) error {
+	_, err := c.cache.Store(ctx, correlationId, key, response, timeout)
+	return err
+}
+
+// requestScopedHeaders are response headers of a particular request that are not replayed from stored responses.
+var requestScopedHeaders = []string{string(PipCorrelationId), TraceParentHeader, TraceStateHeader}
+
+// copyResponseHeader copies response headers except the excluded ones.
+func copyResponseHeader(header http.Header, exclude ...string) http.Header {
+	result := make(http.Header, len(header))
+	for name, values := range header {
+		result[name] = values
+	}
+	for _, name := range exclude {
+		result.Del(name)
+	}
+	return result
+}
+
+// Idempotent wraps a route action to honor "Idempotency-Key" header.
+// The first response for a key (status, headers and body) is kept in IdempotencyStore and replayed
+// for repeated requests of the same user with "Idempotent-Replayed" header. Replayed responses keep
+// correlation id and trace context headers of the repeated request. Reusing a key with a different
+// request body or while the first request is in progress results in 409 Conflict. Server errors
+// are not kept, so failed requests can be retried. Requests without the header are processed as usual.
+//
+//	Parameters:
+//		- action http.HandlerFunc a route action
+//	Returns: http.HandlerFunc a wrapped action
+//
+//	Example:
+//		c.RegisterRoute(http.MethodPost, "/dummies", schema, c.Idempotent(c.create))
+func (c *RestService) Idempotent(action http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		key := req.Header.Get(IdempotencyKeyHeader)
+		if key == "" || c.IdempotencyStore == nil {
+			action(res, req)
+			return
+		}
+
+		correlationId := c.GetCorrelationId(req)
+		body, err := GetRequestBody(req)
+		if err != nil {
+			c.SendError(res, req, err)
+			return
+		}
+		hash := sha256.New()
+		hash.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+		hash.Write(body)
+		requestHash := hex.EncodeToString(hash.Sum(nil))
+		// Keys are scoped by users, so a user can't get a response to another user by the key
+		userHash := sha256.Sum256([]byte(requestUserId(req)))
+		storeKey := "idempotency:" + req.Method + ":" + req.URL.Path + ":" +
+			hex.EncodeToString(userHash[:]) + ":" + key
+
+		// Concurrent requests with the same key are rejected until the first one completes
+		if _, busy := c.idempotencyInFlight.LoadOrStore(storeKey, true); busy {
+			c.SendError(res, req, cerr.NewConflictError(correlationId, "IDEMPOTENCY_KEY_IN_USE",
+				"Request with the same idempotency key is in progress").WithDetails("key", key))
+			return
+		}
+		defer c.idempotencyInFlight.Delete(storeKey)
+
+		stored, err := c.IdempotencyStore.Retrieve(req.Context(), correlationId, storeKey)
+		if err != nil {
+			c.SendError(res, req, err)
+			return
+		}
+		if stored != nil {
+			if stored.RequestHash != requestHash {
+				c.SendError(res, req, cerr.NewConflictError(correlationId, "IDEMPOTENCY_KEY_REUSED",
+					"Idempotency key was used with a different request").WithDetails("key", key))
+				return
+			}
+			for name, values := range copyResponseHeader(stored.Header, requestScopedHeaders...) {
+				res.Header()[name] = values
+			}
+			res.Header().Set(IdempotentReplayedHeader, "true")
+			res.WriteHeader(stored.Status)
+			_, _ = res.Write(stored.Body)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: res, status: http.StatusOK}
+		action(recorder, req)
+
+		// Server errors are not kept, so retries can succeed
+		if recorder.status >= 500 {
+			return
+		}
+		err = c.IdempotencyStore.Store(req.Context(), correlationId, storeKey, &IdempotentResponse{
+			RequestHash: requestHash,
+			Status:      recorder.status,
+			Header:      copyResponseHeader(res.Header(), requestScopedHeaders...),
+			Body:        recorder.body.Bytes(),
+		}, c.IdempotencyTimeout)
+		if err != nil {
+			c.Logger.Error(req.Context(), correlationId, err, "Failed to store response for idempotency key %s", key)
+		}
+	}
+}
+
+// responseRecorder writes a response through and keeps its status and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (c *responseRecorder) WriteHeader(status int) {
+	c.status = status
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *responseRecorder) Write(data []byte) (int, error) {
+	c.body.Write(data)
+	return c.ResponseWriter.Write(data)
+}
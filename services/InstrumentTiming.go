@@ -16,6 +16,7 @@ type InstrumentTiming struct {
 	counters      ccount.ICounters
 	counterTiming *ccount.CounterTiming
 	traceTiming   *ctrace.TraceTiming
+	traceContext  *TraceContext
 }
 
 func NewInstrumentTiming(correlationId string, name string,
@@ -36,6 +37,31 @@ func NewInstrumentTiming(correlationId string, name string,
 	}
 }
 
+// WithTraceContext sets W3C trace context of the instrumented operation.
+// The trace context is passed to tracers in the context when the timing ends.
+func (c *InstrumentTiming) WithTraceContext(traceContext *TraceContext) *InstrumentTiming {
+	c.traceContext = traceContext
+	return c
+}
+
+// TraceContext returns W3C trace context of the instrumented operation or nil.
+func (c *InstrumentTiming) TraceContext() *TraceContext {
+	return c.traceContext
+}
+
+func (c *InstrumentTiming) withTraceContext(ctx context.Context) context.Context {
+	if c.traceContext == nil {
+		return ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := GetTraceContext(ctx); ok {
+		return ctx
+	}
+	return WithTraceContext(ctx, c.traceContext)
+}
+
 func (c *InstrumentTiming) clear() {
 	// Clear references to avoid double processing
 	c.counters = nil
@@ -53,6 +79,7 @@ func (c *InstrumentTiming) EndTiming(ctx context.Context, err error) {
 }
 
 func (c *InstrumentTiming) EndSuccess(ctx context.Context) {
+	ctx = c.withTraceContext(ctx)
 	if c.counterTiming != nil {
 		c.counterTiming.EndTiming(ctx)
 	}
@@ -64,6 +91,7 @@ func (c *InstrumentTiming) EndSuccess(ctx context.Context) {
 }
 
 func (c *InstrumentTiming) EndFailure(ctx context.Context, err error) {
+	ctx = c.withTraceContext(ctx)
 	if c.counterTiming != nil {
 		c.counterTiming.EndTiming(ctx)
 	}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"encoding/json"
+)
+
+// JsonSerializer encodes and decodes values in JSON format ("application/json").
+type JsonSerializer struct {
+}
+
+// NewJsonSerializer creates a new JSON serializer.
+//
+//	Returns: *JsonSerializer
+func NewJsonSerializer() *JsonSerializer {
+	return &JsonSerializer{}
+}
+
+// ContentType returns "application/json" media type.
+func (c *JsonSerializer) ContentType() string {
+	return JsonContentType
+}
+
+// Serialize encodes the value as JSON.
+//
+//	Parameters:
+//		- value any a value to encode
+//	Returns: []byte, error encoded data or an error
+func (c *JsonSerializer) Serialize(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Deserialize decodes JSON data.
+//
+//	Parameters:
+//		- data   []byte JSON data
+//		- target any    a pointer to a value to decode the data to
+//	Returns: error
+func (c *JsonSerializer) Deserialize(data []byte, target any) error {
+	return json.Unmarshal(data, target)
+}
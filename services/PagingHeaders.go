@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+)
+
+const (
+	TotalCountHeader = "X-Total-Count"
+	LinkHeader       = "Link"
+)
+
+// WithPagingHeaders returns a copy of the context that makes HttpResponseSender
+// send "X-Total-Count" and "Link" (RFC 5988) headers for DataPage and TokenizedDataPage results.
+//
+//	Parameters:
+//		- ctx     context.Context
+//		- enabled bool true to send paging headers
+//	Returns: context.Context a new context
+func WithPagingHeaders(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, PipPagingHeaders, enabled)
+}
+
+// IsPagingHeadersEnabled checks if paging headers are enabled in the context.
+//
+//	Parameters:
+//		- ctx context.Context
+//	Returns: bool true if paging headers are sent
+func IsPagingHeadersEnabled(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	enabled, _ := ctx.Value(PipPagingHeaders).(bool)
+	return enabled
+}
+
+// AddPagingHeaders wraps a route action to send paging headers with its data page results.
+// Use it to enable paging headers for a single route.
+//
+//	Parameters:
+//		- action http.HandlerFunc a route action
+//	Returns: http.HandlerFunc a wrapped action
+//
+//	Example:
+//		c.RegisterRoute(http.MethodGet, "/dummies", nil, services.AddPagingHeaders(c.getPageByFilter))
+func AddPagingHeaders(action http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		action(res, req.WithContext(WithPagingHeaders(req.Context(), true)))
+	}
+}
+
+// setPagingHeaders sets "X-Total-Count" and "Link" headers for data pages.
+// Links are built from the request URL with changed skip and take parameters,
+// or token parameter for tokenized pages.
+func setPagingHeaders(res http.ResponseWriter, req *http.Request, result any) {
+	value := reflect.ValueOf(result)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct || value.Type().PkgPath() != dataPagePkgPath {
+		return
+	}
+
+	links := make([]string, 0, 4)
+	typeName := value.Type().Name()
+	switch {
+	case strings.HasPrefix(typeName, "DataPage["):
+		total := int(value.FieldByName("Total").Int())
+		count := value.FieldByName("Data").Len()
+		hasTotal := total >= count
+		if hasTotal {
+			res.Header().Set(TotalCountHeader, strconv.Itoa(total))
+		}
+
+		query := req.URL.Query()
+		skip := cconv.LongConverter.ToLongWithDefault(query.Get("skip"), 0)
+		if skip < 0 {
+			skip = 0
+		}
+		take := cconv.LongConverter.ToLongWithDefault(query.Get("take"), 0)
+		if take <= 0 {
+			take = int64(count)
+		}
+		if take <= 0 {
+			take = cdata.DefaultTake
+		}
+
+		if (hasTotal && skip+int64(count) < int64(total)) || (!hasTotal && int64(count) >= take) {
+			links = append(links, pageLink(req, "next", skip+take, take))
+		}
+		if skip > 0 {
+			prev := skip - take
+			if prev < 0 {
+				prev = 0
+			}
+			links = append(links, pageLink(req, "prev", prev, take))
+		}
+		links = append(links, pageLink(req, "first", 0, take))
+		if hasTotal && total > 0 {
+			links = append(links, pageLink(req, "last", (int64(total)-1)/take*take, take))
+		}
+	case strings.HasPrefix(typeName, "TokenizedDataPage["):
+		if token := value.FieldByName("Token").String(); token != "" {
+			links = append(links, tokenLink(req, "next", token))
+		}
+		links = append(links, tokenLink(req, "first", ""))
+	default:
+		return
+	}
+
+	res.Header().Set(LinkHeader, strings.Join(links, ", "))
+}
+
+func pageLink(req *http.Request, rel string, skip int64, take int64) string {
+	return buildLink(req, rel, func(query url.Values) {
+		query.Set("skip", strconv.FormatInt(skip, 10))
+		query.Set("take", strconv.FormatInt(take, 10))
+	})
+}
+
+func tokenLink(req *http.Request, rel string, token string) string {
+	return buildLink(req, rel, func(query url.Values) {
+		if token == "" {
+			query.Del("token")
+		} else {
+			query.Set("token", token)
+		}
+	})
+}
+
+func buildLink(req *http.Request, rel string, update func(query url.Values)) string {
+	link := *req.URL
+	link.Scheme = "http"
+	if req.TLS != nil {
+		link.Scheme = "https"
+	}
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		link.Scheme = proto
+	}
+	link.Host = req.Host
+
+	query := link.Query()
+	update(query)
+	link.RawQuery = query.Encode()
+	return "<" + link.String() + ">; rel=\"" + rel + "\""
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+)
+
+const (
+	ProblemJsonContentType = "application/problem+json"
+
+	// ErrorFormatPip sends errors as ErrorDescription objects.
+	ErrorFormatPip = "pip"
+	// ErrorFormatProblem sends errors as RFC 7807 problem details.
+	ErrorFormatProblem = "problem"
+
+	DefaultProblemTypeUri = "urn:pip-services:error:"
+)
+
+// ProblemDetails is an error response in "application/problem+json" format (RFC 7807).
+// Extension members are serialized next to the standard members.
+type ProblemDetails struct {
+	// Type is a URI that identifies the problem type.
+	Type string
+	// Title is a short human-readable summary of the problem type.
+	Title string
+	// Status is the HTTP status code.
+	Status int
+	// Detail is a human-readable explanation specific to this occurrence of the problem.
+	Detail string
+	// Instance is a URI reference that identifies the specific occurrence of the problem.
+	Instance string
+	// Extensions are additional members of the problem details.
+	Extensions map[string]any
+}
+
+var problemDetailsMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// NewProblemDetailsFromError maps an error onto problem details.
+// The type is composed from the type URI prefix, error category and code. The correlation id
+// becomes the instance, and the error code, category and details are passed as extensions.
+//
+//	Parameters:
+//		- err     error  an error to convert
+//		- typeUri string a prefix of problem type URIs, DefaultProblemTypeUri is used when it is empty
+//	Returns: *ProblemDetails
+func NewProblemDetailsFromError(err error, typeUri string) *ProblemDetails {
+	if typeUri == "" {
+		typeUri = DefaultProblemTypeUri
+	}
+	desc := withFieldValidationErrors(cerr.ErrorDescriptionFactory.Create(err))
+
+	title := http.StatusText(desc.Status)
+	if title == "" {
+		title = desc.Category
+	}
+
+	extensions := make(map[string]any)
+	for key, value := range desc.Details {
+		extensions[key] = value
+	}
+	// Pip-specific fields override details with the same names to keep them restorable
+	extensions["code"] = desc.Code
+	extensions["category"] = desc.Category
+	if desc.Cause != "" {
+		extensions["cause"] = desc.Cause
+	}
+
+	return &ProblemDetails{
+		Type:       typeUri + desc.Category + "/" + desc.Code,
+		Title:      title,
+		Status:     desc.Status,
+		Detail:     desc.Message,
+		Instance:   desc.CorrelationId,
+		Extensions: extensions,
+	}
+}
+
+// ToErrorDescription converts problem details back to ErrorDescription.
+// Category and code are taken from extensions, when they are missing the category
+// is detected by the status code. Other extensions become error details.
+//
+//	Returns: *cerr.ErrorDescription
+func (c *ProblemDetails) ToErrorDescription() *cerr.ErrorDescription {
+	desc := &cerr.ErrorDescription{
+		Type:          "Application",
+		Status:        c.Status,
+		Message:       c.Detail,
+		CorrelationId: c.Instance,
+	}
+	if desc.Message == "" {
+		desc.Message = c.Title
+	}
+
+	details := make(map[string]any)
+	for key, value := range c.Extensions {
+		switch key {
+		case "code":
+			desc.Code, _ = value.(string)
+		case "category":
+			desc.Category, _ = value.(string)
+		case "cause":
+			desc.Cause, _ = value.(string)
+		default:
+			details[key] = value
+		}
+	}
+	if len(details) > 0 {
+		desc.Details = details
+	}
+	if desc.Category == "" {
+		desc.Category = problemCategoryFromStatus(c.Status)
+	}
+	return desc
+}
+
+func problemCategoryFromStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return cerr.BadRequest
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return cerr.Unauthorized
+	case http.StatusNotFound:
+		return cerr.NotFound
+	case http.StatusConflict:
+		return cerr.Conflict
+	case http.StatusUnsupportedMediaType, http.StatusNotImplemented:
+		return cerr.Unsupported
+	case http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return cerr.NoResponse
+	}
+	if status >= 500 {
+		return cerr.Internal
+	}
+	return cerr.Unknown
+}
+
+// MarshalJSON serializes problem details with extension members at the top level.
+func (c ProblemDetails) MarshalJSON() ([]byte, error) {
+	result := make(map[string]any)
+	for key, value := range c.Extensions {
+		result[key] = value
+	}
+	result["type"] = c.Type
+	result["title"] = c.Title
+	result["status"] = c.Status
+	if c.Detail != "" {
+		result["detail"] = c.Detail
+	}
+	if c.Instance != "" {
+		result["instance"] = c.Instance
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON deserializes problem details collecting unknown members as extensions.
+func (c *ProblemDetails) UnmarshalJSON(data []byte) error {
+	values := make(map[string]any)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	c.Type, _ = values["type"].(string)
+	c.Title, _ = values["title"].(string)
+	if status, ok := values["status"].(float64); ok {
+		c.Status = int(status)
+	}
+	c.Detail, _ = values["detail"].(string)
+	c.Instance, _ = values["instance"].(string)
+
+	for _, member := range problemDetailsMembers {
+		delete(values, member)
+	}
+	c.Extensions = values
+	return nil
+}
+
+// ProblemDetailsOptions defines how errors are converted to problem details.
+type ProblemDetailsOptions struct {
+	// TypeUri is a prefix of problem type URIs.
+	TypeUri string
+}
+
+// WithProblemDetails returns a copy of the context that makes HttpResponseSender
+// send errors as problem details. Passing nil options switches back to ErrorDescription format.
+//
+//	Parameters:
+//		- ctx     context.Context
+//		- options *ProblemDetailsOptions problem details options or nil
+//	Returns: context.Context a new context
+func WithProblemDetails(ctx context.Context, options *ProblemDetailsOptions) context.Context {
+	return context.WithValue(ctx, PipProblemDetails, options)
+}
+
+// GetProblemDetailsOptions retrieves problem details options stored in the context.
+//
+//	Parameters:
+//		- ctx context.Context
+//	Returns: *ProblemDetailsOptions options and true or nil and false if errors are sent as ErrorDescription.
+func GetProblemDetailsOptions(ctx context.Context) (*ProblemDetailsOptions, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	options, ok := ctx.Value(PipProblemDetails).(*ProblemDetailsOptions)
+	return options, ok && options != nil
+}
+
+// newProblemDetailsOptions creates problem details options for the configured error format
+// or returns nil for ErrorDescription format.
+func newProblemDetailsOptions(errorFormat string, typeUri string) *ProblemDetailsOptions {
+	if !strings.EqualFold(strings.TrimSpace(errorFormat), ErrorFormatProblem) {
+		return nil
+	}
+	return &ProblemDetailsOptions{TypeUri: typeUri}
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+
+	"github.com/gorilla/mux"
+)
+
+// DefaultProfilingAllowedIps allows profiling requests only from the local host.
+const DefaultProfilingAllowedIps = "127.0.0.1,::1"
+
+// ProfilingRestService is a service that exposes Go runtime profiles of net/http/pprof via HTTP protocol.
+// The profiles can be analyzed with "go tool pprof", i.e. go tool pprof http://localhost:8080/debug/pprof/heap
+//
+//	The service is disabled by default. When it is enabled it responds on the following routes:
+//		- /debug/pprof/               index of available profiles
+//		- /debug/pprof/profile        CPU profile, "seconds" parameter sets the duration (default: 30)
+//		- /debug/pprof/trace          execution trace, "seconds" parameter sets the duration (default: 1)
+//		- /debug/pprof/heap           heap profile, also allocs, goroutine, block, mutex and threadcreate
+//		- /debug/pprof/cmdline        command line of the process
+//		- /debug/pprof/symbol         program counters lookup
+//
+//	Requests are allowed only from the addresses in allowed_ips and pass
+//	an authorization interceptor when it is set with SetAuthorize.
+//
+//	Configuration parameters:
+//		- base_route:                 base route for remote URI (default: "")
+//		- route:                      route to profiles (default: "debug/pprof")
+//		- options:
+//			- enabled:                register profiling routes (default: false)
+//			- allowed_ips:            comma-separated IP addresses or CIDR networks allowed to get profiles,
+//			                          "*" to allow any address (default: "127.0.0.1,::1")
+//			- block_profile_rate:     rate of blocking events in block profile, see runtime.SetBlockProfileRate (default: 0)
+//			- mutex_profile_fraction: fraction of contention events in mutex profile, see runtime.SetMutexProfileFraction (default: 0)
+//		- dependencies:
+//			- endpoint:               override for HTTP Endpoint dependency
+//		- connection(s):
+//			- discovery_key:          (optional) a key to retrieve the connection from IDiscovery
+//			- protocol:               connection protocol: http or https
+//			- host:                   host name or IP address
+//			- port:                   port number
+//			- uri:                    resource URI or connection string with all parameters in it
+//
+//	References:
+//		- *:logger:*:*:1.0       (optional)  ILogger components to pass log messages
+//		- *:counters:*:*:1.0     (optional)  ICounters components to pass collected measurements
+//		- *:discovery:*:*:1.0    (optional)  IDiscovery services to resolve connection
+//		- *:endpoint:http:*:1.0  (optional) HttpEndpoint reference
+//
+//	see RestService
+//
+//	Example:
+//		service := NewProfilingRestService();
+//		service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+//			"options.enabled", true,
+//			"options.allowed_ips", "10.0.0.0/8",
+//			"connection.protocol", "http",
+//			"connection.host", "localhost",
+//			"connection.port", 8080,
+//		));
+//		service.SetAuthorize((&auth.RoleAuthManager{}).Admin())
+//
+//		opnErr := service.Open(context.Background(), "123")
+//		if opnErr == nil {
+//			fmt.Println("Profiles are accessible at http://+:8080/debug/pprof/");
+//		}
+type ProfilingRestService struct {
+	*RestService
+	route                string
+	enabled              bool
+	allowedIps           []*net.IPNet
+	allowAnyIp           bool
+	blockProfileRate     int
+	mutexProfileFraction int
+	authorize            func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc)
+}
+
+// NewProfilingRestService creates a new instance of this service.
+func NewProfilingRestService() *ProfilingRestService {
+	c := &ProfilingRestService{}
+	c.RestService = InheritRestService(c)
+	c.route = "debug/pprof"
+	c.allowedIps = parseAllowedIps(DefaultProfilingAllowedIps)
+	return c
+}
+
+// Configure component by passing configuration parameters.
+//	Parameters:
+//		- ctx context.Context
+//		- config configuration parameters to be set.
+func (c *ProfilingRestService) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.RestService.Configure(ctx, config)
+	c.route = config.GetAsStringWithDefault("route", c.route)
+	c.enabled = config.GetAsBooleanWithDefault("options.enabled", c.enabled)
+	if allowedIps, ok := config.GetAsNullableString("options.allowed_ips"); ok {
+		c.allowAnyIp = strings.TrimSpace(allowedIps) == "*"
+		c.allowedIps = parseAllowedIps(allowedIps)
+	}
+	c.blockProfileRate = config.GetAsIntegerWithDefault("options.block_profile_rate", c.blockProfileRate)
+	c.mutexProfileFraction = config.GetAsIntegerWithDefault("options.mutex_profile_fraction", c.mutexProfileFraction)
+}
+
+// SetAuthorize sets an authorization interceptor for profiling routes, i.e. from auth managers.
+// It must be called before the service is opened.
+//	Parameters:
+//		- authorize  an authorization interceptor, nil to check only client addresses
+func (c *ProfilingRestService) SetAuthorize(authorize func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc)) {
+	c.authorize = authorize
+}
+
+// IsEnabled checks if profiling routes are registered.
+func (c *ProfilingRestService) IsEnabled() bool {
+	return c.enabled
+}
+
+// Register all service routes in HTTP endpoint.
+func (c *ProfilingRestService) Register() {
+	if !c.enabled {
+		return
+	}
+
+	if c.blockProfileRate > 0 {
+		runtime.SetBlockProfileRate(c.blockProfileRate)
+	}
+	if c.mutexProfileFraction > 0 {
+		runtime.SetMutexProfileFraction(c.mutexProfileFraction)
+	}
+
+	route := strings.TrimSuffix(c.route, "/")
+	c.registerProfile(route, c.index)
+	c.registerProfile(route+"/", c.index)
+	c.registerProfile(route+"/cmdline", pprof.Cmdline)
+	c.registerProfile(route+"/profile", pprof.Profile)
+	c.registerProfile(route+"/symbol", pprof.Symbol)
+	c.registerProfile(route+"/trace", pprof.Trace)
+	c.registerProfile(route+"/{name}", c.index)
+
+	c.Logger.Warn(context.Background(), "", "Profiling is enabled at %s", c.appendBaseRoute(route))
+}
+
+func (c *ProfilingRestService) registerProfile(route string, action http.HandlerFunc) {
+	authorize := func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		if !c.isAllowedIp(req) {
+			HttpResponseSender.SendError(res, req,
+				cerr.NewUnauthorizedError(c.GetCorrelationId(req), "ADDRESS_NOT_ALLOWED",
+					"Client address is not allowed to get profiles").WithStatus(http.StatusForbidden))
+			return
+		}
+		if c.authorize != nil {
+			c.authorize(res, req, next)
+		} else {
+			next(res, req)
+		}
+	}
+	c.RegisterRouteWithAuth(http.MethodGet, route, nil, authorize, action)
+	c.RegisterRouteWithAuth(http.MethodPost, route, nil, authorize, action)
+}
+
+// index serves the profiles index and named profiles.
+// pprof.Index expects "/debug/pprof/" paths, so requests are rewritten to serve profiles under any route.
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *ProfilingRestService) index(res http.ResponseWriter, req *http.Request) {
+	path := "/debug/pprof/" + mux.Vars(req)["name"]
+	if !strings.HasSuffix(req.URL.Path, "/") && path == "/debug/pprof/" {
+		// Relative links in the index work only under a route with trailing slash
+		http.Redirect(res, req, req.URL.Path+"/", http.StatusMovedPermanently)
+		return
+	}
+	profileReq := req.Clone(req.Context())
+	profileReq.URL.Path = path
+	profileReq.URL.RawPath = ""
+	pprof.Index(res, profileReq)
+}
+
+func (c *ProfilingRestService) isAllowedIp(req *http.Request) bool {
+	if c.allowAnyIp {
+		return true
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range c.allowedIps {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAllowedIps parses comma-separated IP addresses and CIDR networks.
+func parseAllowedIps(value string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" || item == "*" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			if ip := net.ParseIP(item); ip != nil {
+				bits := 8 * net.IPv6len
+				if ip.To4() != nil {
+					ip = ip.To4()
+					bits = 8 * net.IPv4len
+				}
+				networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			}
+			continue
+		}
+		if _, network, err := net.ParseCIDR(item); err == nil {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// requestBody caches the raw and decoded request body, so it is read and parsed only once
+// by validation, RestService.DecodeBody and commandable handlers.
+type requestBody struct {
+	read    bool
+	data    []byte
+	readErr error
+
+	decoded   bool
+	value     any
+	decodeErr error
+}
+
+// withRequestBodyCache returns a copy of the request with an empty body cache in its context.
+func withRequestBodyCache(req *http.Request) *http.Request {
+	if _, ok := req.Context().Value(PipRequestBody).(*requestBody); ok {
+		return req
+	}
+	return req.WithContext(context.WithValue(req.Context(), PipRequestBody, &requestBody{}))
+}
+
+// GetRequestBody reads the raw request body. The first read result is cached in the request context
+// set up by HttpEndpoint, and the body is reset, so it can still be read by handlers.
+//
+//	Parameters:
+//		- req *http.Request a HTTP request object.
+//	Returns: []byte, error body data or a read error
+func GetRequestBody(req *http.Request) ([]byte, error) {
+	cache, ok := req.Context().Value(PipRequestBody).(*requestBody)
+	if ok && cache.read {
+		return cache.data, cache.readErr
+	}
+
+	var data []byte
+	var err error
+	if req.Body != nil {
+		data, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	if ok {
+		cache.read = true
+		cache.data = data
+		cache.readErr = err
+	}
+	return data, err
+}
+
+// GetRequestBodyValue decodes the request body into a generic value, i.e. map[string]any,
+// with a serializer picked by "Content-Type" header. The result is cached in the request context.
+// Empty bodies are decoded as nil.
+//
+//	Parameters:
+//		- req *http.Request a HTTP request object.
+//	Returns: any, error a decoded value or an error
+func GetRequestBodyValue(req *http.Request) (any, error) {
+	cache, ok := req.Context().Value(PipRequestBody).(*requestBody)
+	if ok && cache.decoded {
+		return cache.value, cache.decodeErr
+	}
+
+	data, err := GetRequestBody(req)
+	var value any
+	if err == nil && len(data) > 0 {
+		err = Serializers.DecodeRequestBody(req, data, &value)
+	}
+
+	if ok {
+		cache.decoded = true
+		cache.value = value
+		cache.decodeErr = err
+	}
+	return value, err
+}
+
+// decodeRequestBody decodes the cached request body into the target.
+func decodeRequestBody(req *http.Request, target any) error {
+	data, err := GetRequestBody(req)
+	if err != nil {
+		return err
+	}
+	return Serializers.DecodeRequestBody(req, data, target)
+}
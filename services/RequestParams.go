@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cvalid "github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+)
+
+const (
+	// SortParamName is a query parameter with sort fields, i.e. "sort=name,-created".
+	// Fields with "-" prefix are sorted in descending order.
+	SortParamName = "sort"
+	// ProjectionParamName is a query parameter with projection fields, i.e. "fields=a,b.c".
+	ProjectionParamName = "fields"
+)
+
+// getRequestParam gets a request parameter by its name from query parameters or route variables.
+// When a query parameter is repeated the first value is returned.
+func getRequestParam(req *http.Request, name string) string {
+	param := req.URL.Query().Get(name)
+	if param == "" {
+		param = mux.Vars(req)[name]
+	}
+	return param
+}
+
+// getRequestParamAsArray gets all values of a request parameter. Values are collected from repeated
+// query parameters (?id=1&id=2) and comma lists (?id=1,2), or from a route variable with a comma list.
+// Values of repeated query parameters are not split, so they can contain commas.
+func getRequestParamAsArray(req *http.Request, name string) []string {
+	if values, ok := req.URL.Query()[name]; ok {
+		return splitQueryValues(values)
+	}
+	if value, ok := mux.Vars(req)[name]; ok {
+		return splitParamValues([]string{value})
+	}
+	return nil
+}
+
+// getRequestParamAsInt gets a request parameter converted to integer.
+func getRequestParamAsInt(req *http.Request, name string) (int, bool) {
+	param := getRequestParam(req, name)
+	if param == "" {
+		return 0, false
+	}
+	return cconv.IntegerConverter.ToNullableInteger(param)
+}
+
+// getRequestParamAsTime gets a request parameter converted to time, i.e. in RFC 3339 format.
+func getRequestParamAsTime(req *http.Request, name string) (time.Time, bool) {
+	param := getRequestParam(req, name)
+	if param == "" {
+		return time.Time{}, false
+	}
+	return cconv.DateTimeConverter.ToNullableDateTime(param)
+}
+
+// getRequestTokenizedPagingParams gets tokenized paging from token, take and total parameters.
+func getRequestTokenizedPagingParams(req *http.Request) *cdata.TokenizedPagingParams {
+	return cdata.NewTokenizedPagingParamsFromTuples(
+		"token", getRequestParam(req, "token"),
+		"take", getRequestParam(req, "take"),
+		"total", getRequestParam(req, "total"),
+	)
+}
+
+// getRequestSortParams gets sort fields from "sort" parameter.
+func getRequestSortParams(req *http.Request) *cdata.SortParams {
+	fields := make([]cdata.SortField, 0)
+	// Field names have no commas, so every value is split as a comma list
+	for _, value := range splitParamValues(req.URL.Query()[SortParamName]) {
+		// "+" in query strings is decoded as space, so the value is trimmed
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, "-"):
+			fields = append(fields, cdata.NewSortField(strings.TrimPrefix(value, "-"), false))
+		case strings.HasPrefix(value, "+"):
+			fields = append(fields, cdata.NewSortField(strings.TrimPrefix(value, "+"), true))
+		default:
+			fields = append(fields, cdata.NewSortField(value, true))
+		}
+	}
+	return cdata.NewSortParams(fields)
+}
+
+// getRequestProjectionParams gets projection fields from "fields" parameter.
+// Nested fields can be set with dots (a.b) or brackets (a(b,c)).
+func getRequestProjectionParams(req *http.Request) *cdata.ProjectionParams {
+	values, ok := req.URL.Query()[ProjectionParamName]
+	if !ok {
+		if value, ok := mux.Vars(req)[ProjectionParamName]; ok {
+			values = []string{value}
+		}
+	}
+	return cdata.ParseProjectionParams(values...)
+}
+
+// splitQueryValues gets items of a query parameter. Repeated parameters (?id=1&id=2) are taken as they are,
+// so their values can contain commas, and a single parameter is split as a comma list (?id=1,2).
+// Empty items are skipped.
+func splitQueryValues(values []string) []string {
+	if len(values) == 1 {
+		return splitParamValues(values)
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// splitParamValues splits comma lists in parameter values, empty items are skipped.
+func splitParamValues(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// GetRequestParams gets request parameters: query parameters, route variables and "body".
+// For routes with a validation schema it returns parameters that were validated,
+// with values coerced to the types declared in the schema, i.e. "123" to int64 for TypeCode.Long.
+// For other routes it returns query parameters and route variables as strings.
+// The returned map is shared and must not be modified.
+//
+//	Parameters:
+//		- req *http.Request a HTTP request object.
+//	Returns: map[string]any request parameters
+func GetRequestParams(req *http.Request) map[string]any {
+	if params, ok := req.Context().Value(PipRequestParams).(map[string]any); ok {
+		return params
+	}
+	params := make(map[string]any)
+	putRequestParams(params, req, nil)
+	return params
+}
+
+// withRequestParams returns a copy of the request with parameters in its context.
+func withRequestParams(req *http.Request, params map[string]any) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), PipRequestParams, params))
+}
+
+// putRequestParams puts query parameters and route variables into parameters for validation.
+// Parameters defined as arrays in the schema receive all values, others only the first one.
+// Values are converted to the types declared in the schema, values that can't be converted
+// are kept as strings, so validation reports them.
+func putRequestParams(params map[string]any, req *http.Request, properties []*cvalid.PropertySchema) {
+	types := make(map[string]any)
+	for _, property := range properties {
+		types[property.Name()] = property.Type()
+	}
+
+	for k, v := range req.URL.Query() {
+		if _, ok := types[k].(*cvalid.ArraySchema); ok {
+			v = splitQueryValues(v)
+		}
+		params[k] = coerceParamValues(v, types[k])
+	}
+	for k, v := range mux.Vars(req) {
+		values := []string{v}
+		if _, ok := types[k].(*cvalid.ArraySchema); ok {
+			values = splitParamValues(values)
+		}
+		params[k] = coerceParamValues(values, types[k])
+	}
+}
+
+// validateRequestParams validates parameters by the schema and converts parameters
+// the schema expects of other types. Types are taken from the validation results,
+// so parameters of routes registered with a plain schema, i.e. a Schema of an ObjectSchema,
+// are converted as well. Parameters are validated again after they are converted:
+// the first pass converts values and arrays, the second one items of the arrays.
+func validateRequestParams(params map[string]any, req *http.Request, schema *cvalid.Schema) []*cvalid.ValidationResult {
+	results := schema.Validate(params)
+	for pass := 0; pass < 2; pass++ {
+		if !coerceMismatchedParams(params, req, results) {
+			break
+		}
+		results = schema.Validate(params)
+	}
+	return results
+}
+
+// coerceMismatchedParams converts request parameters reported by validation as values of other types.
+// It returns true when any parameter has been converted.
+func coerceMismatchedParams(params map[string]any, req *http.Request, results []*cvalid.ValidationResult) bool {
+	coerced := false
+	for _, result := range results {
+		name, item, isItem := strings.Cut(result.Path(), ".")
+		if name == "body" {
+			continue
+		}
+		switch {
+		case result.Code() == "VALUE_ISNOT_ARRAY" && !isItem:
+			if _, ok := params[name].(string); !ok {
+				continue
+			}
+			values := getRequestParamAsArray(req, name)
+			items := make([]any, len(values))
+			for i, value := range values {
+				items[i] = value
+			}
+			params[name] = items
+			coerced = true
+		case result.Code() == "TYPE_MISMATCH" && !isItem:
+			value, ok := params[name].(string)
+			if !ok {
+				continue
+			}
+			if converted := coerceParamValue(value, result.Expected()); converted != any(value) {
+				params[name] = converted
+				coerced = true
+			}
+		case result.Code() == "TYPE_MISMATCH":
+			items, ok := params[name].([]any)
+			index, err := strconv.Atoi(item)
+			if !ok || err != nil || index < 0 || index >= len(items) {
+				continue
+			}
+			value, ok := items[index].(string)
+			if !ok {
+				continue
+			}
+			if converted := coerceParamValue(value, result.Expected()); converted != any(value) {
+				items[index] = converted
+				coerced = true
+			}
+		}
+	}
+	return coerced
+}
+
+// coerceParamValues converts parameter values to the type, array values are expected to be split.
+func coerceParamValues(values []string, typ any) any {
+	array, ok := typ.(*cvalid.ArraySchema)
+	if !ok {
+		return coerceParamValue(values[0], typ)
+	}
+	result := make([]any, len(values))
+	for i, item := range values {
+		result[i] = coerceParamValue(item, array.ValueType())
+	}
+	return result
+}
+
+func coerceParamValue(value string, typ any) any {
+	typeCode, ok := typ.(cconv.TypeCode)
+	if !ok {
+		return value
+	}
+	switch typeCode {
+	case cconv.Boolean, cconv.Integer, cconv.Long, cconv.Float, cconv.Double, cconv.DateTime, cconv.Duration:
+		if result, ok := cconv.TypeConverter.ToNullableType(typeCode, value); ok {
+			return result
+		}
+	}
+	return value
+}
+
+// objectSchemaProperties returns properties of an object schema or nil for other schemas.
+func objectSchemaProperties(schema cvalid.ISchema) []*cvalid.PropertySchema {
+	if objectSchema, ok := schema.(*cvalid.ObjectSchema); ok && objectSchema != nil {
+		return objectSchema.Properties()
+	}
+	return nil
+}
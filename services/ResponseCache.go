@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	ccache "github.com/pip-services3-gox/pip-services3-components-gox/cache"
+)
+
+const (
+	ResponseCacheHeader         = "X-Cache"
+	DefaultResponseCacheTimeout = 60 * 1000
+)
+
+// CachedResponse is a response of a GET route kept in the response cache.
+type CachedResponse struct {
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+	// Header contains response headers.
+	Header http.Header `json:"header"`
+	// Body is the response body.
+	Body []byte `json:"body"`
+}
+
+// NewMemoryResponseCache creates a new response cache that keeps responses in memory.
+//
+//	Returns: ccache.ICache[*CachedResponse]
+func NewMemoryResponseCache() ccache.ICache[*CachedResponse] {
+	return ccache.NewMemoryCache[*CachedResponse]()
+}
+
+// cacheScopedHeaders are response headers of a particular request or user that are not cached.
+var cacheScopedHeaders = append([]string{"Set-Cookie", ResponseCacheHeader}, requestScopedHeaders...)
+
+// CacheResponse wraps a GET route action to keep its successful responses in ResponseCache.
+// Responses are cached by the group, request path, query, headers from ResponseCacheHeaders
+// and user id from ResponseCacheUserId. Correlation id, trace context and cookie headers are not cached. Requests with "Cache-Control: no-cache" skip the cached response
+// and refresh it, requests with "Cache-Control: no-store" bypass the cache.
+// Sent responses have "X-Cache" header set to HIT or MISS. Hits and misses are counted
+// as "<base_route>.<group>.cache_hits" and "<base_route>.<group>.cache_misses".
+//
+//	Parameters:
+//		- group   string a cache group used to invalidate responses
+//		- timeout int64 time in milliseconds to keep responses, 0 to use ResponseCacheTimeout
+//		- action  http.HandlerFunc a route action
+//	Returns: http.HandlerFunc a wrapped action
+//
+//	Example:
+//		c.RegisterRoute(http.MethodGet, "/dummies", nil, c.CacheResponse("dummies", 0, c.getPageByFilter))
+//		c.RegisterRoute(http.MethodPost, "/dummies", nil, c.InvalidateResponses(c.create, "dummies"))
+func (c *RestService) CacheResponse(group string, timeout int64, action http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		cacheControl := strings.ToLower(req.Header.Get("Cache-Control"))
+		if c.ResponseCache == nil || req.Method != http.MethodGet || strings.Contains(cacheControl, "no-store") {
+			action(res, req)
+			return
+		}
+
+		ctx := req.Context()
+		correlationId := c.GetCorrelationId(req)
+		ttl := timeout
+		if ttl <= 0 {
+			ttl = c.ResponseCacheTimeout
+		}
+		generation, err := c.responseCacheGeneration(ctx, correlationId, group, ttl)
+		if err != nil {
+			c.Logger.Error(ctx, correlationId, err, "Failed to retrieve cache generation of %s", group)
+			action(res, req)
+			return
+		}
+		key := c.responseCacheKey(group, generation, req)
+
+		if !strings.Contains(cacheControl, "no-cache") {
+			cached, err := c.ResponseCache.Retrieve(ctx, correlationId, key)
+			if err != nil {
+				c.Logger.Error(ctx, correlationId, err, "Failed to retrieve cached response for %s", req.URL.Path)
+			}
+			if cached != nil {
+				c.Counters.IncrementOne(ctx, c.BaseRoute+"."+group+".cache_hits")
+				for name, values := range copyResponseHeader(cached.Header, cacheScopedHeaders...) {
+					res.Header()[name] = values
+				}
+				res.Header().Set(ResponseCacheHeader, "HIT")
+				res.WriteHeader(cached.Status)
+				_, _ = res.Write(cached.Body)
+				return
+			}
+		}
+		c.Counters.IncrementOne(ctx, c.BaseRoute+"."+group+".cache_misses")
+
+		res.Header().Set(ResponseCacheHeader, "MISS")
+		recorder := &responseRecorder{ResponseWriter: res, status: http.StatusOK}
+		action(recorder, req)
+		if recorder.status != http.StatusOK {
+			return
+		}
+
+		_, err = c.ResponseCache.Store(ctx, correlationId, key, &CachedResponse{
+			Status: recorder.status,
+			Header: copyResponseHeader(res.Header(), cacheScopedHeaders...),
+			Body:   recorder.body.Bytes(),
+		}, ttl)
+		if err != nil {
+			c.Logger.Error(ctx, correlationId, err, "Failed to store cached response for %s", req.URL.Path)
+		}
+	}
+}
+
+// InvalidateResponses wraps a mutating route action to remove cached responses
+// of the groups after the action succeeds.
+//
+//	Parameters:
+//		- action http.HandlerFunc a route action
+//		- groups ...string cache groups to invalidate
+//	Returns: http.HandlerFunc a wrapped action
+func (c *RestService) InvalidateResponses(action http.HandlerFunc, groups ...string) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		recorder := &responseRecorder{ResponseWriter: res, status: http.StatusOK}
+		action(recorder, req)
+		if recorder.status >= 400 {
+			return
+		}
+
+		correlationId := c.GetCorrelationId(req)
+		if err := c.ClearResponseCache(req.Context(), correlationId, groups...); err != nil {
+			c.Logger.Error(req.Context(), correlationId, err, "Failed to invalidate cached responses")
+		}
+	}
+}
+
+// ClearResponseCache invalidates cached responses of the groups. Keys of cached responses contain
+// a generation of their group kept in ResponseCache, so a new generation invalidates responses
+// of all services that share the cache. Invalidated responses are removed when they expire.
+//
+//	Parameters:
+//		- ctx           context.Context
+//		- correlationId string (optional) transaction id to trace execution through call chain.
+//		- groups        ...string cache groups to clear
+//	Returns: error
+func (c *RestService) ClearResponseCache(ctx context.Context, correlationId string, groups ...string) error {
+	if c.ResponseCache == nil {
+		return nil
+	}
+
+	for _, group := range groups {
+		if _, err := c.newResponseCacheGeneration(ctx, correlationId, group, c.ResponseCacheTimeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// responseCacheGeneration gets the current generation of the group or starts a new one.
+// A generation that has expired is never reused, so expired generations don't return stale responses.
+func (c *RestService) responseCacheGeneration(ctx context.Context, correlationId string,
+	group string, timeout int64) (string, error) {
+
+	stored, err := c.ResponseCache.Retrieve(ctx, correlationId, responseCacheGenerationKey(group))
+	if err != nil {
+		return "", err
+	}
+	if stored != nil && len(stored.Body) > 0 {
+		return string(stored.Body), nil
+	}
+	return c.newResponseCacheGeneration(ctx, correlationId, group, timeout)
+}
+
+// newResponseCacheGeneration starts a new generation of the group.
+// The generation is kept as a cached response, so it can be stored in any ResponseCache.
+func (c *RestService) newResponseCacheGeneration(ctx context.Context, correlationId string,
+	group string, timeout int64) (string, error) {
+
+	generation := uuid.NewString()
+	_, err := c.ResponseCache.Store(ctx, correlationId, responseCacheGenerationKey(group),
+		&CachedResponse{Body: []byte(generation)}, timeout)
+	return generation, err
+}
+
+func responseCacheGenerationKey(group string) string {
+	return "response-generation:" + group
+}
+
+func (c *RestService) responseCacheKey(group string, generation string, req *http.Request) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.URL.Path + "?" + req.URL.Query().Encode() + "\n"))
+	for _, name := range c.ResponseCacheHeaders {
+		hash.Write([]byte(name + ": " + req.Header.Get(name) + "\n"))
+	}
+	if c.ResponseCacheUserId != nil {
+		hash.Write([]byte("user: " + c.ResponseCacheUserId(req)))
+	}
+	return "response:" + group + ":" + generation + ":" + hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+)
+
+var dataPagePkgPath = reflect.TypeOf(cdata.DataPage[any]{}).PkgPath()
+
+// WithResponseProjection returns a copy of the context that makes HttpResponseSender
+// apply projection from "fields" parameter to sent results.
+//
+//	Parameters:
+//		- ctx     context.Context
+//		- enabled bool true to apply projection
+//	Returns: context.Context a new context
+func WithResponseProjection(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, PipResponseProjection, enabled)
+}
+
+// IsResponseProjectionEnabled checks if projection of results is enabled in the context.
+//
+//	Parameters:
+//		- ctx context.Context
+//	Returns: bool true if results are projected
+func IsResponseProjectionEnabled(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	enabled, _ := ctx.Value(PipResponseProjection).(bool)
+	return enabled
+}
+
+// ProjectResponse wraps a route action to apply projection from "fields" parameter to its results.
+// Use it to enable projection for a single route.
+//
+//	Parameters:
+//		- action http.HandlerFunc a route action
+//	Returns: http.HandlerFunc a wrapped action
+//
+//	Example:
+//		c.RegisterRoute(http.MethodGet, "/dummies", nil, services.ProjectResponse(c.getPageByFilter))
+func ProjectResponse(action http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		action(res, req.WithContext(WithResponseProjection(req.Context(), true)))
+	}
+}
+
+// ApplyProjection keeps only the projected fields in a value.
+// Nested fields are set with dots (a.b), arrays are projected item by item
+// and DataPage or TokenizedDataPage results are projected by their data items.
+// The value is converted through JSON, so field names match json tags.
+//
+//	Parameters:
+//		- value      any                     a value to project
+//		- projection *cdata.ProjectionParams projection fields
+//	Returns: any, error the projected value or a conversion error
+func ApplyProjection(value any, projection *cdata.ProjectionParams) (any, error) {
+	if value == nil || projection == nil || projection.Len() == 0 {
+		return value, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	// Numbers are kept as json.Number to preserve precision of large integers
+	var generic any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	tree := newProjectionTree(projection.Value())
+	if page, ok := generic.(map[string]any); ok && isDataPage(value) {
+		result := make(map[string]any, len(page))
+		for key, item := range page {
+			result[key] = item
+		}
+		result["data"] = tree.apply(page["data"])
+		return result, nil
+	}
+	return tree.apply(generic), nil
+}
+
+func isDataPage(value any) bool {
+	typ := reflect.TypeOf(value)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.PkgPath() == dataPagePkgPath &&
+		(strings.HasPrefix(typ.Name(), "DataPage[") || strings.HasPrefix(typ.Name(), "TokenizedDataPage["))
+}
+
+// projectionTree is a tree of projected fields, a nil subtree includes the whole field.
+type projectionTree map[string]projectionTree
+
+func newProjectionTree(fields []string) projectionTree {
+	tree := make(projectionTree)
+	for _, field := range fields {
+		node := tree
+		names := strings.Split(field, ".")
+		for i, name := range names {
+			if name == "" {
+				break
+			}
+			child, ok := node[name]
+			if ok && child == nil {
+				// The whole field is already included
+				break
+			}
+			if i == len(names)-1 {
+				node[name] = nil
+				break
+			}
+			if !ok {
+				child = make(projectionTree)
+				node[name] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+func (c projectionTree) apply(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any)
+		for name, subtree := range c {
+			item, ok := v[name]
+			if !ok {
+				continue
+			}
+			if subtree == nil {
+				result[name] = item
+			} else {
+				result[name] = subtree.apply(item)
+			}
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = c.apply(item)
+		}
+		return result
+	}
+	return value
+}
@@ -1,13 +1,10 @@
 package services
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"io/ioutil"
 	"net/http"
+	"time"
 
-	"github.com/gorilla/mux"
 	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
 	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
 	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
@@ -59,11 +56,7 @@ func (c *RestOperations) SetReferences(ctx context.Context, references crefer.IR
 //		- req *http.Request  request
 //	Returns: string correlation_id or empty string
 func (c *RestOperations) GetCorrelationId(req *http.Request) string {
-	correlationId := req.URL.Query().Get("correlation_id")
-	if correlationId == "" {
-		correlationId = req.Header.Get("correlation_id")
-	}
-	return correlationId
+	return getRequestCorrelationId(req, DefaultCorrelationIdHeaders)
 }
 
 // GetFilterParams method reruns filter params object from request
@@ -77,12 +70,34 @@ func (c *RestOperations) GetFilterParams(req *http.Request) *cdata.FilterParams
 	delete(params, "skip")
 	delete(params, "take")
 	delete(params, "total")
+	delete(params, "token")
+	delete(params, SortParamName)
+	delete(params, ProjectionParamName)
 	filter := cdata.NewFilterParamsFromValue(
 		params,
 	)
 	return filter
 }
 
+// GetSortParams method gets sort parameters from "sort" parameter, i.e. "sort=name,-created".
+// Fields with "-" prefix are sorted in descending order.
+//
+//	Parameters:
+//		- req incoming request
+//	Returns: *cdata.SortParams sort parameters
+func (c *RestOperations) GetSortParams(req *http.Request) *cdata.SortParams {
+	return getRequestSortParams(req)
+}
+
+// GetProjectionParams method gets projection parameters from "fields" parameter, i.e. "fields=a,b.c".
+//
+//	Parameters:
+//		- req incoming request
+//	Returns: *cdata.ProjectionParams projection parameters
+func (c *RestOperations) GetProjectionParams(req *http.Request) *cdata.ProjectionParams {
+	return getRequestProjectionParams(req)
+}
+
 // GetPagingParams method reruns paging params object from request
 //
 //	Parameters:
@@ -103,6 +118,15 @@ func (c *RestOperations) GetPagingParams(req *http.Request) *cdata.PagingParams
 	return paging
 }
 
+// GetTokenizedPagingParams method reruns tokenized (cursor) paging params object from request
+//
+//	Parameters:
+//		- req *http.Request  request
+//	Returns: *cdata.TokenizedPagingParams tokenized paging params object
+func (c *RestOperations) GetTokenizedPagingParams(req *http.Request) *cdata.TokenizedPagingParams {
+	return getRequestTokenizedPagingParams(req)
+}
+
 // GetParam methods helps get all params from query
 //
 //		Parameters:
@@ -111,14 +135,53 @@ func (c *RestOperations) GetPagingParams(req *http.Request) *cdata.PagingParams
 //
 // Returns: value or empty string if param not exists
 func (c *RestOperations) GetParam(req *http.Request, name string) string {
-	param := req.URL.Query().Get(name)
-	if param == "" {
-		param = mux.Vars(req)[name]
-	}
-	return param
+	return getRequestParam(req, name)
 }
 
-// DecodeBody methods helps decode body
+// GetParams method gets validated request parameters with values coerced to the types
+// declared in the route schema, i.e. numeric ids and boolean flags.
+//
+//	Parameters:
+//		- req  incoming request
+//	Returns: map[string]any query parameters, route variables and "body"
+func (c *RestOperations) GetParams(req *http.Request) map[string]any {
+	return GetRequestParams(req)
+}
+
+// GetParamAsArray method gets all values of a parameter from repeated query parameters
+// (?id=1&id=2), a comma list (?id=1,2) or a route variable. Values of repeated parameters are not split.
+//
+//	Parameters:
+//		- req  incoming request
+//		- name parameter name
+//	Returns: []string parameter values or nil if param not exists
+func (c *RestOperations) GetParamAsArray(req *http.Request, name string) []string {
+	return getRequestParamAsArray(req, name)
+}
+
+// GetParamAsInt method gets a parameter from query or route converted to integer.
+//
+//	Parameters:
+//		- req  incoming request
+//		- name parameter name
+//	Returns: int, bool value and true or 0 and false if param not exists or can't be converted
+func (c *RestOperations) GetParamAsInt(req *http.Request, name string) (int, bool) {
+	return getRequestParamAsInt(req, name)
+}
+
+// GetParamAsTime method gets a parameter from query or route converted to time, i.e. in RFC 3339 format.
+//
+//	Parameters:
+//		- req  incoming request
+//		- name parameter name
+//	Returns: time.Time, bool value and true or zero time and false if param not exists or can't be converted
+func (c *RestOperations) GetParamAsTime(req *http.Request, name string) (time.Time, bool) {
+	return getRequestParamAsTime(req, name)
+}
+
+// DecodeBody methods helps decode body.
+// The body is decoded by a serializer registered for "Content-Type" of the request (JSON by default).
+// The raw body is read once and cached in the request context, so it can be decoded repeatedly.
 //
 //	Parameters:
 //		- req incoming request
@@ -126,22 +189,7 @@ func (c *RestOperations) GetParam(req *http.Request, name string) string {
 //
 // Returns: error
 func (c *RestOperations) DecodeBody(req *http.Request, target any) error {
-	bodyBytes, err := ioutil.ReadAll(req.Body)
-
-	if err != nil {
-		return err
-	}
-
-	err = json.Unmarshal(bodyBytes, target)
-
-	if err != nil {
-		return err
-	}
-
-	_ = req.Body.Close()
-	req.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
-
-	return nil
+	return decodeRequestBody(req, target)
 }
 
 func (c *RestOperations) SendResult(res http.ResponseWriter, req *http.Request, result any, err error) {
@@ -160,6 +208,24 @@ func (c *RestOperations) SendDeletedResult(res http.ResponseWriter, req *http.Re
 	HttpResponseSender.SendDeletedResult(res, req, result, err)
 }
 
+func (c *RestOperations) SendStream(res http.ResponseWriter, req *http.Request, items <-chan any) {
+	HttpResponseSender.SendStream(res, req, items)
+}
+
+func (c *RestOperations) SendEventStream(res http.ResponseWriter, req *http.Request,
+	events <-chan *ServerSentEvent, heartbeat time.Duration) {
+	HttpResponseSender.SendEventStream(res, req, events, heartbeat)
+}
+
+// GetLastEventId method returns id of the last event received by the client
+// from "Last-Event-ID" header or "last_event_id" query parameter.
+//	Parameters:
+//		- req  incoming request
+//	Returns: last event id or empty string to start stream from the beginning
+func (c *RestOperations) GetLastEventId(req *http.Request) string {
+	return getLastEventId(req)
+}
+
 func (c *RestOperations) SendError(res http.ResponseWriter, req *http.Request, err error) {
 	HttpResponseSender.SendError(res, req, err)
 }
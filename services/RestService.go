@@ -1,20 +1,23 @@
 package services
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/gorilla/mux"
 	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
 	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
 	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
 	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
 	crefer "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
 	cvalid "github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	ccache "github.com/pip-services3-gox/pip-services3-components-gox/cache"
 	ccount "github.com/pip-services3-gox/pip-services3-components-gox/count"
 	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
 	ctrace "github.com/pip-services3-gox/pip-services3-components-gox/trace"
@@ -24,6 +27,7 @@ import (
 //
 //	Configuration parameters:
 //		- base_route:              base route for remote URI
+//		- version:                 (optional) API version of the service routes, i.e. "v1"
 //		- dependencies:
 //			- endpoint:            override for HTTP Endpoint dependency
 //			- controller:          override for Controller dependency
@@ -37,12 +41,33 @@ import (
 //			- ssl_key_file:        the SSL private key in PEM
 //			- ssl_crt_file:        the SSL certificate in PEM
 //			- ssl_ca_file:         the certificate authorities (root cerfiticates) in PEM
+//		- options:
+//			- generate_correlation_id: generate correlation id when request doesn't have one (default: false)
+//			- correlation_id_headers:  a comma-separated list of headers with correlation id in order of precedence
+//			- error_format:            format of error responses: "pip" or "problem" for RFC 7807 problem details.
+//			                           When it is set, it overrides the endpoint format for routes under the base route
+//			- problem_type_uri:        a prefix of problem type URIs (default: "urn:pip-services:error:")
+//			- response_projection:     apply projection from "fields" parameter to results of the service routes (default: false)
+//			- paging_headers:          send "X-Total-Count" and "Link" headers with data pages of the service routes (default: false)
+//			- idempotency_timeout:     time in milliseconds to keep responses for idempotency keys (default: 24 hours)
+//			- response_cache_timeout:  default time in milliseconds to keep cached responses (default: 60000)
+//			- response_cache_headers:  comma-separated request headers that distinguish cached responses (default: "Accept")
+//			- version_selection:       how requests select the version: "path" for a route prefix, "header" for "Accept-Version"
+//			                           header or "media_type" for "Accept" header (default: "path")
+//			- version_default:         serve requests that don't select a version by headers (default: false)
+//			- deprecated:              send "Deprecation" header with responses of the version (default: false)
+//			- deprecation_date:        (optional) date when the version was deprecated, sent in "Deprecation" header
+//			- sunset_date:             (optional) date when the version stops working, sent in "Sunset" header
 //
 //	References:
 //		- *:logger:*:*:1.0         (optional) ILogger components to pass log messages
 //		- *:counters:*:*:1.0       (optional) ICounters components to pass collected measurements
 //		- *:discovery:*:*:1.0      (optional) IDiscovery services to resolve connection
 //		- *:endpoint:http:*:1.0    (optional) HttpEndpoint reference
+//		- *:response-cache:*:*:1.0 (optional) ICache[any], i.e. a distributed cache, or ICache[*CachedResponse]
+//		                           to keep cached responses
+//		- *:idempotency-store:*:*:1.0 (optional) IIdempotencyStore or ICache[any] to keep responses for idempotency keys
+//		- *:swagger-service:*:*:1.0 (optional) ISwaggerService to register OpenAPI documents, i.e. SwaggerRestService
 //
 //	See clients.RestClient
 //
@@ -116,6 +141,8 @@ type RestService struct {
 	opened        bool
 	//The base route.
 	BaseRoute string
+	//The API version of the service routes, nil when routes are not versioned.
+	ApiVersion *ApiVersion
 	//The HTTP endpoint that exposes this service.
 	Endpoint *HttpEndpoint
 	//The dependency resolver.
@@ -130,6 +157,28 @@ type RestService struct {
 	SwaggerService ISwaggerService
 	SwaggerEnabled bool
 	SwaggerRoute   string
+
+	// The store of responses for routes wrapped by Idempotent, in memory by default.
+	IdempotencyStore IIdempotencyStore
+	// The time in milliseconds to keep responses for idempotency keys.
+	IdempotencyTimeout  int64
+	idempotencyInFlight sync.Map
+
+	// The cache of responses for routes wrapped by CacheResponse, in memory by default.
+	ResponseCache ccache.ICache[*CachedResponse]
+	// The default time in milliseconds to keep cached responses.
+	ResponseCacheTimeout int64
+	// The request headers that distinguish cached responses.
+	ResponseCacheHeaders []string
+	// Gets id of the user that distinguishes cached responses. By default it is the authenticated user id
+	// from PipAuthUserId context value or, when it is not set, the "Authorization" header,
+	// so responses of different credentials are cached separately.
+	ResponseCacheUserId func(req *http.Request) string
+
+	errorFormatSet     bool
+	problemDetails     *ProblemDetailsOptions
+	responseProjection bool
+	pagingHeaders      bool
 }
 
 // InheritRestService creates new instance of RestService
@@ -141,6 +190,8 @@ func InheritRestService(overrides IRegisterable) *RestService {
 		"base_route", "",
 		"dependencies.endpoint", "*:endpoint:http:*:1.0",
 		"dependencies.swagger", "*:swagger-service:*:*:1.0",
+		"dependencies.response_cache", "*:response-cache:*:*:1.0",
+		"dependencies.idempotency_store", "*:idempotency-store:*:*:1.0",
 	)
 	rs.DependencyResolver = crefer.NewDependencyResolver()
 	rs.DependencyResolver.Configure(context.TODO(), rs.defaultConfig)
@@ -149,6 +200,12 @@ func InheritRestService(overrides IRegisterable) *RestService {
 	rs.Tracer = ctrace.NewCompositeTracer()
 	rs.SwaggerEnabled = false
 	rs.SwaggerRoute = "swagger"
+	rs.IdempotencyStore = NewMemoryIdempotencyStore()
+	rs.IdempotencyTimeout = DefaultIdempotencyTimeout
+	rs.ResponseCache = NewMemoryResponseCache()
+	rs.ResponseCacheTimeout = DefaultResponseCacheTimeout
+	rs.ResponseCacheHeaders = []string{"Accept"}
+	rs.ResponseCacheUserId = requestUserId
 	return &rs
 }
 
@@ -161,8 +218,37 @@ func (c *RestService) Configure(ctx context.Context, config *cconf.ConfigParams)
 	c.config = config
 	c.DependencyResolver.Configure(ctx, config)
 	c.BaseRoute = config.GetAsStringWithDefault("base_route", c.BaseRoute)
+	if version := config.GetAsString("version"); version != "" {
+		c.ApiVersion = NewApiVersion(version)
+		c.ApiVersion.Selection = config.GetAsStringWithDefault("options.version_selection", VersionSelectionPath)
+		c.ApiVersion.Default = config.GetAsBoolean("options.version_default")
+		c.ApiVersion.Deprecated = config.GetAsBoolean("options.deprecated")
+		if date, ok := config.GetAsNullableDateTime("options.deprecation_date"); ok {
+			c.ApiVersion.DeprecationDate = date
+		}
+		if date, ok := config.GetAsNullableDateTime("options.sunset_date"); ok {
+			c.ApiVersion.SunsetDate = date
+		}
+	}
 	c.SwaggerEnabled = config.GetAsBooleanWithDefault("swagger.enable", c.SwaggerEnabled)
 	c.SwaggerRoute = config.GetAsStringWithDefault("swagger.route", c.SwaggerRoute)
+
+	errorFormat, ok := config.GetAsNullableString("options.error_format")
+	c.errorFormatSet = ok
+	c.problemDetails = newProblemDetailsOptions(errorFormat,
+		config.GetAsStringWithDefault("options.problem_type_uri", DefaultProblemTypeUri))
+	c.responseProjection = config.GetAsBooleanWithDefault("options.response_projection", c.responseProjection)
+	c.pagingHeaders = config.GetAsBooleanWithDefault("options.paging_headers", c.pagingHeaders)
+	c.IdempotencyTimeout = config.GetAsLongWithDefault("options.idempotency_timeout", c.IdempotencyTimeout)
+	c.ResponseCacheTimeout = config.GetAsLongWithDefault("options.response_cache_timeout", c.ResponseCacheTimeout)
+	if headers, ok := config.GetAsNullableString("options.response_cache_headers"); ok {
+		c.ResponseCacheHeaders = make([]string, 0)
+		for _, header := range strings.Split(headers, ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				c.ResponseCacheHeaders = append(c.ResponseCacheHeaders, header)
+			}
+		}
+	}
 }
 
 // SetReferences method are sets references to dependent components.
@@ -195,10 +281,36 @@ func (c *RestService) SetReferences(ctx context.Context, references crefer.IRefe
 
 	depRes = c.DependencyResolver.GetOneOptional("swagger")
 	if depRes != nil {
-		if _val, ok := depRes.(ISwaggerService); ok {
+		// A swagger service doesn't register its own routes
+		if _val, ok := depRes.(ISwaggerService); ok && depRes != any(c.Overrides) {
 			c.SwaggerService = _val
 		}
 	}
+
+	// Caches created by factories keep any values, so they are adapted to cached types
+	depRes = c.DependencyResolver.GetOneOptional("response_cache")
+	if depRes != nil {
+		switch cache := depRes.(type) {
+		case ccache.ICache[*CachedResponse]:
+			c.ResponseCache = cache
+		case ccache.ICache[any]:
+			c.ResponseCache = NewTypedCache[*CachedResponse](cache)
+		default:
+			c.Logger.Warn(ctx, "", "Response cache %T is not ICache[any] or ICache[*CachedResponse] and is ignored", depRes)
+		}
+	}
+
+	depRes = c.DependencyResolver.GetOneOptional("idempotency_store")
+	if depRes != nil {
+		switch store := depRes.(type) {
+		case IIdempotencyStore:
+			c.IdempotencyStore = store
+		case ccache.ICache[any]:
+			c.IdempotencyStore = NewCacheIdempotencyStore(NewTypedCache[*IdempotentResponse](store))
+		default:
+			c.Logger.Warn(ctx, "", "Idempotency store %T is not IIdempotencyStore or ICache[any] and is ignored", depRes)
+		}
+	}
 }
 
 // UnsetReferences method are unsets (clears) previously set references to dependent components.
@@ -211,6 +323,12 @@ func (c *RestService) UnsetReferences() {
 	c.SwaggerService = nil
 }
 
+// GetConfig method returns the effective configuration of this service.
+//	Returns: *cconf.ConfigParams configuration parameters with defaults, nil when the service was not configured
+func (c *RestService) GetConfig() *cconf.ConfigParams {
+	return c.config
+}
+
 func (c *RestService) createEndpoint(ctx context.Context) *HttpEndpoint {
 	endpoint := NewHttpEndpoint()
 
@@ -226,20 +344,28 @@ func (c *RestService) createEndpoint(ctx context.Context) *HttpEndpoint {
 
 // Instrument method are adds instrumentation to log calls and measure call time.
 // It returns a Timing object that is used to end the time measurement.
+// W3C trace context extracted by the endpoint is taken from the context and passed to tracers.
 //	Parameters:
 //		- ctx context.Context
 //		- correlationId     (optional) transaction id to trace execution through call chain.
 //		- name              a method name.
 //	Returns: Timing object to end the time measurement.
 func (c *RestService) Instrument(ctx context.Context, correlationId string, name string) *InstrumentTiming {
-	c.Logger.Trace(ctx, correlationId, "Executing %s method", name)
+	traceContext, ok := GetTraceContext(ctx)
+	if ok {
+		c.Logger.Trace(ctx, correlationId, "Executing %s method in trace %s span %s (parent %s)",
+			name, traceContext.TraceId, traceContext.SpanId, traceContext.ParentId)
+	} else {
+		c.Logger.Trace(ctx, correlationId, "Executing %s method", name)
+	}
 	c.Counters.IncrementOne(ctx, name+".exec_count")
 
 	counterTiming := c.Counters.BeginTiming(ctx, name+".exec_time")
 	traceTiming := c.Tracer.BeginTrace(ctx, correlationId, name, "")
 
 	return NewInstrumentTiming(correlationId, name, "exec",
-		c.Logger, c.Counters, counterTiming, traceTiming)
+		c.Logger, c.Counters, counterTiming, traceTiming).
+		WithTraceContext(traceContext)
 }
 
 // InstrumentError method are adds instrumentation to error handling.
@@ -374,14 +500,75 @@ func (c *RestService) SendError(res http.ResponseWriter, req *http.Request, err
 	HttpResponseSender.SendError(res, req, err)
 }
 
+// SendStream method are sends items from the channel as NDJSON or chunked JSON array
+// without buffering the whole result. Streams are encoded as JSON only, requests that accept
+// other media types are rejected. An error received from the channel ends the stream.
+// It blocks until the channel is closed or the client disconnects.
+// The producer must close the channel and should stop sending when req.Context() is done,
+// items left after a client disconnect are drained in background.
+//	Parameters:
+//		- req       a HTTP request object.
+//		- res       a HTTP response object.
+//		- items     a channel with items or an error to send
+//
+//	Example:
+//		items := make(chan any)
+//		go func() {
+//			defer close(items)
+//			for _, item := range data {
+//				select {
+//				case items <- item:
+//				case <-req.Context().Done():
+//					return
+//				}
+//			}
+//		}()
+//		c.SendStream(res, req, items)
+func (c *RestService) SendStream(res http.ResponseWriter, req *http.Request, items <-chan any) {
+	HttpResponseSender.SendStream(res, req, items)
+}
+
+// SendEventStream method are sends events from the channel as Server-Sent Events.
+// It blocks until the channel is closed or the client disconnects.
+// The producer must close the channel and should stop sending when req.Context() is done.
+//	Parameters:
+//		- req       a HTTP request object.
+//		- res       a HTTP response object.
+//		- events    a channel with events to send
+//		- heartbeat an interval between heartbeat comments, 0 disables heartbeats
+func (c *RestService) SendEventStream(res http.ResponseWriter, req *http.Request,
+	events <-chan *ServerSentEvent, heartbeat time.Duration) {
+	HttpResponseSender.SendEventStream(res, req, events, heartbeat)
+}
+
+// GetLastEventId method returns id of the last event received by the client
+// from "Last-Event-ID" header or "last_event_id" query parameter.
+//	Parameters:
+//		- req  incoming request
+//	Returns: last event id or empty string to start stream from the beginning
+func (c *RestService) GetLastEventId(req *http.Request) string {
+	return getLastEventId(req)
+}
+
+// versionedBaseRoute gets the base route prefixed with the version selected by path, i.e. "v1/dummies".
+func (c *RestService) versionedBaseRoute() string {
+	if c.ApiVersion == nil || !c.ApiVersion.IsSelectedByPath() {
+		return c.BaseRoute
+	}
+	version := strings.Trim(c.ApiVersion.Version, "/")
+	if baseRoute := strings.Trim(c.BaseRoute, "/"); baseRoute != "" {
+		return version + "/" + baseRoute
+	}
+	return version
+}
+
 func (c *RestService) appendBaseRoute(route string) string {
 
 	if route == "" {
 		route = "/"
 	}
 
-	if c.BaseRoute != "" && len(c.BaseRoute) > 0 {
-		baseRoute := c.BaseRoute
+	if baseRoute := c.versionedBaseRoute(); baseRoute != "" {
 		if len(route) == 0 {
 			route = "/"
 		}
@@ -406,11 +593,7 @@ func (c *RestService) appendBaseRoute(route string) string {
 func (c *RestService) RegisterRoute(method string, route string, schema *cvalid.Schema,
 	action func(res http.ResponseWriter, req *http.Request)) {
 
-	if c.Endpoint == nil {
-		return
-	}
-	route = c.appendBaseRoute(route)
-	c.Endpoint.RegisterRoute(method, route, schema, action)
+	c.RegisterRouteWithAuth(method, route, schema, nil, action)
 }
 
 // RegisterRouteWithAuth method are registers a route with authorization in HTTP endpoint.
@@ -424,19 +607,118 @@ func (c *RestService) RegisterRouteWithAuth(method string, route string, schema
 	authorize func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc),
 	action func(res http.ResponseWriter, req *http.Request)) {
 
+	c.registerRoute(method, route, schema, nil, authorize, action)
+}
+
+// RegisterParamsRoute method are registers a route with an object schema of its parameters in HTTP endpoint.
+// Query parameters and route variables are converted to the types of the schema properties,
+// array properties receive all values of repeated keys or a comma list.
+//	Parameters:
+//		- method        HTTP method: "get", "head", "post", "put", "delete"
+//		- route         a command route. Base route will be added to this route
+//		- schema        an object schema to convert and validate received parameters.
+//		- action        an action function that is called when operation is invoked.
+//
+//	Example:
+//		c.RegisterParamsRoute(http.MethodGet, "/dummies",
+//			cvalid.NewObjectSchema().
+//				WithOptionalProperty("ids", cvalid.NewArraySchema(cconv.String)).
+//				WithOptionalProperty("take", cconv.Integer),
+//			c.getDummies)
+func (c *RestService) RegisterParamsRoute(method string, route string, schema *cvalid.ObjectSchema,
+	action func(res http.ResponseWriter, req *http.Request)) {
+
+	c.RegisterParamsRouteWithAuth(method, route, schema, nil, action)
+}
+
+// RegisterParamsRouteWithAuth method are registers a route with an object schema of its parameters
+// and authorization in HTTP endpoint.
+//	Parameters:
+//		- method        HTTP method: "get", "head", "post", "put", "delete"
+//		- route         a command route. Base route will be added to this route
+//		- schema        an object schema to convert and validate received parameters.
+//		- authorize     an authorization interceptor
+//		- action        an action function that is called when operation is invoked.
+func (c *RestService) RegisterParamsRouteWithAuth(method string, route string, schema *cvalid.ObjectSchema,
+	authorize func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc),
+	action func(res http.ResponseWriter, req *http.Request)) {
+
+	if schema == nil {
+		c.registerRoute(method, route, nil, nil, authorize, action)
+		return
+	}
+	c.registerRoute(method, route, schema.Schema, schema.Properties(), authorize, action)
+}
+
+func (c *RestService) registerRoute(method string, route string, schema *cvalid.Schema,
+	properties []*cvalid.PropertySchema,
+	authorize func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc),
+	action func(res http.ResponseWriter, req *http.Request)) {
+
 	if c.Endpoint == nil {
 		return
 	}
 	route = c.appendBaseRoute(route)
-	c.Endpoint.RegisterRouteWithAuth(
-		method, route, schema,
-		func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	c.Endpoint.registerRoute(
+		method, route, schema, properties, c.ApiVersion, c.prepareRequest,
+		func(res http.ResponseWriter, req *http.Request) {
 			if authorize != nil {
-				authorize(res, req, next)
+				authorize(res, req, action)
 			} else {
-				next.ServeHTTP(res, req)
+				action(res, req)
 			}
-		}, action)
+		})
+}
+
+// RegisterStaticFiles method are registers a route in HTTP endpoint that serves static files
+// from the given file system, i.e. embed.FS with a single-page app.
+//	Parameters:
+//		- route         a files route prefix. Base route will be added to this route
+//		- fileSystem    a file system with static files.
+//		- options       (optional) options to serve files.
+func (c *RestService) RegisterStaticFiles(route string, fileSystem fs.FS, options *StaticFilesOptions) {
+	if c.Endpoint == nil {
+		return
+	}
+	route = c.appendBaseRoute(route)
+	c.Endpoint.RegisterStaticFiles(route, fileSystem, options)
+}
+
+// RegisterStaticDir method are registers a route in HTTP endpoint that serves static files
+// from the given directory.
+//	Parameters:
+//		- route         a files route prefix. Base route will be added to this route
+//		- dir           a path to the directory with static files.
+//		- options       (optional) options to serve files.
+func (c *RestService) RegisterStaticDir(route string, dir string, options *StaticFilesOptions) {
+	c.RegisterStaticFiles(route, os.DirFS(dir), options)
+}
+
+// RegisterWebSocketRoute method are registers a WebSocket route in HTTP endpoint.
+//	Parameters:
+//		- route         a command route. Base route will be added to this route
+//		- handler       a handler function that is called when connection is opened.
+func (c *RestService) RegisterWebSocketRoute(route string,
+	handler func(req *http.Request, conn *WebSocketConnection)) {
+
+	c.RegisterWebSocketRouteWithAuth(route, nil, handler)
+}
+
+// RegisterWebSocketRouteWithAuth method are registers a WebSocket route with authorization in HTTP endpoint.
+// The authorization interceptor is called before the connection is upgraded.
+//	Parameters:
+//		- route         a command route. Base route will be added to this route
+//		- authorize     an authorization interceptor
+//		- handler       a handler function that is called when connection is opened.
+func (c *RestService) RegisterWebSocketRouteWithAuth(route string,
+	authorize func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc),
+	handler func(req *http.Request, conn *WebSocketConnection)) {
+
+	if c.Endpoint == nil {
+		return
+	}
+	route = c.appendBaseRoute(route)
+	c.Endpoint.RegisterWebSocketRoute(route, authorize, handler)
 }
 
 // RegisterInterceptor method are registers a middleware for a given route in HTTP endpoint.
@@ -450,6 +732,17 @@ func (c *RestService) RegisterInterceptor(route string,
 		return
 	}
 	route = c.appendBaseRoute(route)
+	// Versions selected by headers share routes, so interceptors only run for their version
+	if version := c.ApiVersion; version != nil && !version.IsSelectedByPath() {
+		versionAction := action
+		action = func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+			if version.Matches(req) {
+				versionAction(res, req, next)
+			} else {
+				next.ServeHTTP(res, req)
+			}
+		}
+	}
 	c.Endpoint.RegisterInterceptor(route, action)
 }
 
@@ -459,35 +752,59 @@ func (c *RestService) RegisterInterceptor(route string,
 //		- name parameter name
 //	Returns value or empty string if param not exists
 func (c *RestService) GetParam(req *http.Request, name string) string {
-	param := req.URL.Query().Get(name)
-	if param == "" {
-		param = mux.Vars(req)[name]
-	}
-	return param
+	return getRequestParam(req, name)
 }
 
-// DecodeBody methods helps decode body
+// GetParams method gets validated request parameters with values coerced to the types
+// declared in the route schema, i.e. numeric ids and boolean flags.
+//
 //	Parameters:
-//   - req   	- incoming request
-//   - target  	- pointer on target variable for decode
-// Returns error
-func (c *RestService) DecodeBody(req *http.Request, target any) error {
-	bodyBytes, err := ioutil.ReadAll(req.Body)
-
-	if err != nil {
-		return err
-	}
+//		- req  incoming request
+//	Returns: map[string]any query parameters, route variables and "body"
+func (c *RestService) GetParams(req *http.Request) map[string]any {
+	return GetRequestParams(req)
+}
 
-	err = json.Unmarshal(bodyBytes, target)
+// GetParamAsArray method gets all values of a parameter from repeated query parameters
+// (?id=1&id=2), a comma list (?id=1,2) or a route variable. Values of repeated parameters are not split.
+//
+//	Parameters:
+//		- req  incoming request
+//		- name parameter name
+//	Returns: []string parameter values or nil if param not exists
+func (c *RestService) GetParamAsArray(req *http.Request, name string) []string {
+	return getRequestParamAsArray(req, name)
+}
 
-	if err != nil {
-		return err
-	}
+// GetParamAsInt method gets a parameter from query or route converted to integer.
+//
+//	Parameters:
+//		- req  incoming request
+//		- name parameter name
+//	Returns: int, bool value and true or 0 and false if param not exists or can't be converted
+func (c *RestService) GetParamAsInt(req *http.Request, name string) (int, bool) {
+	return getRequestParamAsInt(req, name)
+}
 
-	_ = req.Body.Close()
-	req.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
+// GetParamAsTime method gets a parameter from query or route converted to time, i.e. in RFC 3339 format.
+//
+//	Parameters:
+//		- req  incoming request
+//		- name parameter name
+//	Returns: time.Time, bool value and true or zero time and false if param not exists or can't be converted
+func (c *RestService) GetParamAsTime(req *http.Request, name string) (time.Time, bool) {
+	return getRequestParamAsTime(req, name)
+}
 
-	return nil
+// DecodeBody methods helps decode body.
+// The body is decoded by a serializer registered for "Content-Type" of the request (JSON by default).
+// The raw body is read once and cached in the request context, so it can be decoded repeatedly.
+//	Parameters:
+//   - req   	- incoming request
+//   - target  	- pointer on target variable for decode
+// Returns error
+func (c *RestService) DecodeBody(req *http.Request, target any) error {
+	return decodeRequestBody(req, target)
 }
 
 // GetPagingParams methods helps decode paging params
@@ -504,6 +821,15 @@ func (c *RestService) GetPagingParams(req *http.Request) *cdata.PagingParams {
 	return cdata.NewPagingParamsFromValue(pagingParams)
 }
 
+// GetTokenizedPagingParams methods helps decode tokenized (cursor) paging params:
+// token, take and total.
+//	Parameters:
+//		- req incoming request
+//	Returns: tokenized paging params
+func (c *RestService) GetTokenizedPagingParams(req *http.Request) *cdata.TokenizedPagingParams {
+	return getRequestTokenizedPagingParams(req)
+}
+
 // GetFilterParams methods helps decode filter params
 //	Parameters:
 //		- req incoming request
@@ -515,21 +841,42 @@ func (c *RestService) GetFilterParams(req *http.Request) *cdata.FilterParams {
 	delete(params, "skip")
 	delete(params, "take")
 	delete(params, "total")
+	delete(params, "token")
 	delete(params, "correlation_id")
+	delete(params, SortParamName)
+	delete(params, ProjectionParamName)
 
 	return cdata.NewFilterParamsFromValue(params)
 }
 
+// GetSortParams method gets sort parameters from "sort" parameter, i.e. "sort=name,-created".
+// Fields with "-" prefix are sorted in descending order.
+//
+//	Parameters:
+//		- req incoming request
+//	Returns: *cdata.SortParams sort parameters
+func (c *RestService) GetSortParams(req *http.Request) *cdata.SortParams {
+	return getRequestSortParams(req)
+}
+
+// GetProjectionParams method gets projection parameters from "fields" parameter, i.e. "fields=a,b.c".
+//
+//	Parameters:
+//		- req incoming request
+//	Returns: *cdata.ProjectionParams projection parameters
+func (c *RestService) GetProjectionParams(req *http.Request) *cdata.ProjectionParams {
+	return getRequestProjectionParams(req)
+}
+
 // GetCorrelationId method returns CorrelationId from request
 //	Parameters:
 //		- req *http.Request  request
 //	Returns: string correlation_id or empty string
 func (c *RestService) GetCorrelationId(req *http.Request) string {
-	correlationId := req.URL.Query().Get("correlation_id")
-	if correlationId == "" {
-		correlationId = req.Header.Get("correlation_id")
+	if c.Endpoint != nil {
+		return c.Endpoint.GetCorrelationId(req)
 	}
-	return correlationId
+	return getRequestCorrelationId(req, DefaultCorrelationIdHeaders)
 }
 
 func (c *RestService) RegisterOpenApiSpecFromFile(path string) {
@@ -547,10 +894,20 @@ func (c *RestService) RegisterOpenApiSpecFromFile(path string) {
 	c.RegisterOpenApiSpec((string)(content))
 }
 
+// RegisterOpenApiSpec method are registers a route that serves OpenAPI document of the service.
+// Every API version gets its own document: versions selected by path have it under their
+// route prefix, other versions have it at "<swagger_route>/<version>".
+//	Parameters:
+//		- content   OpenAPI document in YAML or JSON
 func (c *RestService) RegisterOpenApiSpec(content string) {
-	if c.SwaggerEnabled {
-		c.RegisterRoute(http.MethodGet,
-			c.SwaggerRoute, nil, func(res http.ResponseWriter, req *http.Request) {
+	if c.SwaggerEnabled && c.Endpoint != nil {
+		swaggerRoute := c.SwaggerRoute
+		if c.ApiVersion != nil && !c.ApiVersion.IsSelectedByPath() {
+			swaggerRoute = strings.TrimSuffix(swaggerRoute, "/") + "/" + c.ApiVersion.Version
+		}
+		// The document is served to any client, as browsers can't select versions by headers
+		c.Endpoint.RegisterRoute(http.MethodGet,
+			c.appendBaseRoute(swaggerRoute), nil, func(res http.ResponseWriter, req *http.Request) {
 				res.Header().Add("Content-Length", cconv.StringConverter.ToString(len(content)))
 				res.Header().Add("Content-Type", "application/x-yaml")
 				res.WriteHeader(200)
@@ -558,7 +915,10 @@ func (c *RestService) RegisterOpenApiSpec(content string) {
 			})
 
 		if c.SwaggerService != nil {
-			c.SwaggerService.RegisterOpenApiSpec(c.BaseRoute, c.SwaggerRoute)
+			c.SwaggerService.RegisterOpenApiSpec(c.versionedBaseRoute(), swaggerRoute)
+			if contentService, ok := c.SwaggerService.(ISwaggerContentService); ok {
+				contentService.RegisterOpenApiSpecContent(c.versionedBaseRoute(), swaggerRoute, content)
+			}
 		}
 	}
 }
@@ -568,3 +928,22 @@ func (c *RestService) Register() {
 	// Override in child classes
 	c.Overrides.Register()
 }
+
+// prepareRequest sets the service options in the context of requests to the service routes,
+// so they don't affect routes of other services on a shared endpoint.
+// The error format of a shared endpoint is overridden only when it is set in the service configuration,
+// local endpoints are configured with the service configuration.
+func (c *RestService) prepareRequest(req *http.Request) *http.Request {
+	ctx := req.Context()
+	if c.errorFormatSet && !c.localEndpoint {
+		ctx = WithProblemDetails(ctx, c.problemDetails)
+	}
+	if c.responseProjection {
+		ctx = WithResponseProjection(ctx, true)
+	}
+	if c.pagingHeaders {
+		ctx = WithPagingHeaders(ctx, true)
+	}
+	return req.WithContext(ctx)
+}
+
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Serializers is the registry of serializers used by HttpResponseSender, RestService and RestClient
+// to encode and decode HTTP bodies. By default, it contains JSON and CBOR serializers.
+//
+//	Example:
+//		services.Serializers.Register(NewMyMsgPackSerializer())
+var Serializers = NewSerializerRegistry()
+
+// SerializerRegistry keeps serializers by media types and negotiates
+// the format of responses. The first registered serializer is used by default.
+type SerializerRegistry struct {
+	lock        sync.RWMutex
+	serializers []ISerializer
+}
+
+// NewSerializerRegistry creates a new registry with JSON and CBOR serializers.
+//
+//	Returns: *SerializerRegistry
+func NewSerializerRegistry() *SerializerRegistry {
+	c := &SerializerRegistry{}
+	c.Register(NewJsonSerializer())
+	c.Register(NewCborSerializer())
+	return c
+}
+
+// Register adds a serializer or replaces the one registered for the same media type.
+//
+//	Parameters:
+//		- serializer ISerializer a serializer to register
+func (c *SerializerRegistry) Register(serializer ISerializer) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i, registered := range c.serializers {
+		if strings.EqualFold(registered.ContentType(), serializer.ContentType()) {
+			c.serializers[i] = serializer
+			return
+		}
+	}
+	c.serializers = append(c.serializers, serializer)
+}
+
+// ContentTypes gets media types of all registered serializers.
+//
+//	Returns: []string
+func (c *SerializerRegistry) ContentTypes() []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	result := make([]string, len(c.serializers))
+	for i, serializer := range c.serializers {
+		result[i] = serializer.ContentType()
+	}
+	return result
+}
+
+// Default gets the serializer used when a media type is not set or not supported.
+//
+//	Returns: ISerializer
+func (c *SerializerRegistry) Default() ISerializer {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if len(c.serializers) == 0 {
+		return NewJsonSerializer()
+	}
+	return c.serializers[0]
+}
+
+// Get finds a serializer by a value of "Content-Type" header. Media type parameters are ignored.
+// Structured syntax suffixes are supported, i.e. "application/merge-patch+json" is handled by JSON serializer.
+//
+//	Parameters:
+//		- contentType string a media type
+//	Returns: ISerializer, bool a found serializer and true or nil and false if it is not registered.
+func (c *SerializerRegistry) Get(contentType string) (ISerializer, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for _, serializer := range c.serializers {
+		if strings.EqualFold(serializer.ContentType(), mediaType) {
+			return serializer, true
+		}
+	}
+	for _, serializer := range c.serializers {
+		_, subtype, _ := strings.Cut(serializer.ContentType(), "/")
+		if subtype != "" && strings.HasSuffix(mediaType, "+"+strings.ToLower(subtype)) {
+			return serializer, true
+		}
+	}
+	return nil, false
+}
+
+// GetOrDefault finds a serializer by a value of "Content-Type" header
+// and falls back to the default serializer if it is not registered.
+//
+//	Parameters:
+//		- contentType string a media type
+//	Returns: ISerializer
+func (c *SerializerRegistry) GetOrDefault(contentType string) ISerializer {
+	if serializer, ok := c.Get(contentType); ok {
+		return serializer
+	}
+	return c.Default()
+}
+
+// Negotiate picks a serializer for a response by a value of "Accept" header (RFC 9110)
+// taking quality values and wildcards into account.
+// The default serializer is used when the header is empty or no registered media type is acceptable.
+//
+//	Parameters:
+//		- accept string a value of "Accept" header
+//	Returns: ISerializer
+func (c *SerializerRegistry) Negotiate(accept string) ISerializer {
+	if accept == "" {
+		return c.Default()
+	}
+
+	type acceptRange struct {
+		mediaType string
+		quality   float64
+	}
+	ranges := make([]acceptRange, 0)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality > 0 {
+			ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	for _, r := range ranges {
+		if r.mediaType == "*/*" {
+			return c.Default()
+		}
+		if strings.HasSuffix(r.mediaType, "/*") {
+			prefix := strings.TrimSuffix(r.mediaType, "*")
+			c.lock.RLock()
+			for _, serializer := range c.serializers {
+				if strings.HasPrefix(strings.ToLower(serializer.ContentType()), prefix) {
+					c.lock.RUnlock()
+					return serializer
+				}
+			}
+			c.lock.RUnlock()
+			continue
+		}
+		if serializer, ok := c.Get(r.mediaType); ok {
+			return serializer
+		}
+		// Vendor media types with structured syntax suffixes, i.e. application/vnd.mycompany.v1+json
+		if index := strings.LastIndex(r.mediaType, "+"); index > 0 {
+			if serializer, ok := c.Get("application/" + r.mediaType[index+1:]); ok {
+				return serializer
+			}
+		}
+	}
+	return c.Default()
+}
+
+// ForRequest picks a serializer for a response to the request by its "Accept" header.
+//
+//	Parameters:
+//		- req *http.Request a HTTP request object.
+//	Returns: ISerializer
+func (c *SerializerRegistry) ForRequest(req *http.Request) ISerializer {
+	if req == nil {
+		return c.Default()
+	}
+	return c.Negotiate(req.Header.Get("Accept"))
+}
+
+// DecodeRequestBody decodes the body data with a serializer picked by "Content-Type" header of the request.
+// Requests without a registered media type are decoded as JSON for backward compatibility.
+//
+//	Parameters:
+//		- req    *http.Request a HTTP request object.
+//		- data   []byte        body data
+//		- target any           a pointer to a value to decode the data to
+//	Returns: error
+func (c *SerializerRegistry) DecodeRequestBody(req *http.Request, data []byte, target any) error {
+	contentType := ""
+	if req != nil {
+		contentType = req.Header.Get("Content-Type")
+	}
+	return c.GetOrDefault(contentType).Deserialize(data, target)
+}
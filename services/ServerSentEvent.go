@@ -0,0 +1,93 @@
+package services
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+)
+
+const (
+	EventStreamContentType = "text/event-stream"
+	LastEventIdHeader      = "Last-Event-ID"
+)
+
+// ServerSentEvent is an event sent to clients in "text/event-stream" format.
+// See https://html.spec.whatwg.org/multipage/server-sent-events.html
+type ServerSentEvent struct {
+	// Id is an event id that clients send back in "Last-Event-ID" header to resume the stream.
+	Id string `json:"id"`
+	// Event is an event type. Clients treat events without type as "message".
+	Event string `json:"event"`
+	// Data is an event payload.
+	Data string `json:"data"`
+	// Retry is a reconnection time in milliseconds, 0 means it is not set.
+	Retry int `json:"retry"`
+}
+
+// NewServerSentEvent creates a new event. Data that is not a string is serialized as JSON.
+//
+//	Parameters:
+//		- id    string (optional) an event id
+//		- event string (optional) an event type
+//		- data  any    an event payload
+//	Returns: *ServerSentEvent
+func NewServerSentEvent(id string, event string, data any) *ServerSentEvent {
+	var value string
+	switch v := data.(type) {
+	case nil:
+		value = ""
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		value, _ = cconv.JsonConverter.ToJson(data)
+	}
+	return &ServerSentEvent{
+		Id:    id,
+		Event: event,
+		Data:  value,
+	}
+}
+
+// WriteTo writes the event in "text/event-stream" format.
+//
+//	Parameters:
+//		- writer io.Writer a writer to write the event to
+//	Returns: int64, error number of written bytes and an error if write failed
+func (c *ServerSentEvent) WriteTo(writer io.Writer) (int64, error) {
+	var builder strings.Builder
+	if c.Id != "" {
+		builder.WriteString("id: " + removeLineBreaks(c.Id) + "\n")
+	}
+	if c.Event != "" {
+		builder.WriteString("event: " + removeLineBreaks(c.Event) + "\n")
+	}
+	if c.Retry > 0 {
+		builder.WriteString("retry: " + strconv.Itoa(c.Retry) + "\n")
+	}
+	data := strings.ReplaceAll(c.Data, "\r\n", "\n")
+	for _, line := range strings.Split(data, "\n") {
+		builder.WriteString("data: " + line + "\n")
+	}
+	builder.WriteString("\n")
+
+	n, err := io.WriteString(writer, builder.String())
+	return int64(n), err
+}
+
+func removeLineBreaks(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+// getLastEventId reads id of the last received event from "Last-Event-ID" header or "last_event_id" query parameter.
+func getLastEventId(req *http.Request) string {
+	lastEventId := req.Header.Get(LastEventIdHeader)
+	if lastEventId == "" {
+		lastEventId = req.URL.Query().Get("last_event_id")
+	}
+	return lastEventId
+}
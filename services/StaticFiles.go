@@ -0,0 +1,182 @@
+package services
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+)
+
+// StaticFilesOptions defines how static files are served by HttpEndpoint.
+//
+//	Configuration parameters:
+//		- index:            name of the index file in directories (default: "index.html")
+//		- spa:              serve the root index file for unknown paths without extension
+//		                    to support client-side routing of single-page apps (default: false)
+//		- max_age:          max age of cached files in seconds, 0 disables caching (default: 0)
+//		- precompressed:    serve precompressed ".gz" files if client accepts gzip (default: false)
+type StaticFilesOptions struct {
+	// IndexFile is a file served for directory requests.
+	IndexFile string
+	// SpaFallback enables serving the root index file when the requested file is not found.
+	SpaFallback bool
+	// MaxAge is max age of the files in browser caches.
+	MaxAge time.Duration
+	// Precompressed enables serving ".gz" files next to the requested ones.
+	Precompressed bool
+}
+
+// NewStaticFilesOptions creates static files options with default values.
+//
+//	Returns: *StaticFilesOptions
+func NewStaticFilesOptions() *StaticFilesOptions {
+	return &StaticFilesOptions{
+		IndexFile:     "index.html",
+		SpaFallback:   false,
+		MaxAge:        0,
+		Precompressed: false,
+	}
+}
+
+// NewStaticFilesOptionsFromConfig creates static files options from configuration parameters.
+//
+//	Parameters:
+//		- config *cconf.ConfigParams configuration parameters
+//	Returns: *StaticFilesOptions
+func NewStaticFilesOptionsFromConfig(config *cconf.ConfigParams) *StaticFilesOptions {
+	c := NewStaticFilesOptions()
+	if config == nil {
+		return c
+	}
+	c.IndexFile = config.GetAsStringWithDefault("index", c.IndexFile)
+	c.SpaFallback = config.GetAsBooleanWithDefault("spa", c.SpaFallback)
+	c.MaxAge = time.Duration(config.GetAsLongWithDefault("max_age", int64(c.MaxAge/time.Second))) * time.Second
+	c.Precompressed = config.GetAsBooleanWithDefault("precompressed", c.Precompressed)
+	return c
+}
+
+type staticFilesHandler struct {
+	prefix     string
+	fileSystem fs.FS
+	options    *StaticFilesOptions
+}
+
+func newStaticFilesHandler(prefix string, fileSystem fs.FS, options *StaticFilesOptions) *staticFilesHandler {
+	if options == nil {
+		options = NewStaticFilesOptions()
+	}
+	return &staticFilesHandler{
+		prefix:     strings.TrimSuffix(prefix, "/"),
+		fileSystem: fileSystem,
+		options:    options,
+	}
+}
+
+func (c *staticFilesHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	rest := strings.TrimPrefix(req.URL.Path, c.prefix)
+	if rest != "" && rest[0] != '/' {
+		http.NotFound(res, req)
+		return
+	}
+	if strings.ContainsAny(rest, "\\\x00") {
+		http.NotFound(res, req)
+		return
+	}
+
+	// Clean the path to prevent access outside of the file system root
+	name := strings.TrimPrefix(path.Clean("/"+rest), "/")
+	if name == "" {
+		name = "."
+	}
+	if !fs.ValidPath(name) {
+		http.NotFound(res, req)
+		return
+	}
+
+	name, info, err := c.resolve(name)
+	if err != nil && c.options.SpaFallback && path.Ext(name) == "" {
+		name, info, err = c.resolve(c.options.IndexFile)
+	}
+	if err != nil {
+		http.NotFound(res, req)
+		return
+	}
+
+	c.serveFile(res, req, name, info)
+}
+
+func (c *staticFilesHandler) resolve(name string) (string, fs.FileInfo, error) {
+	info, err := fs.Stat(c.fileSystem, name)
+	if err != nil {
+		return name, nil, err
+	}
+	if info.IsDir() {
+		name = path.Join(name, c.options.IndexFile)
+		info, err = fs.Stat(c.fileSystem, name)
+		if err != nil {
+			return name, nil, err
+		}
+		if info.IsDir() {
+			return name, nil, fs.ErrNotExist
+		}
+	}
+	return name, info, nil
+}
+
+func (c *staticFilesHandler) serveFile(res http.ResponseWriter, req *http.Request, name string, info fs.FileInfo) {
+	header := res.Header()
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	// Override no-cache headers set by the endpoint
+	header.Del("Pragma")
+	header.Del("Expires")
+	if c.options.MaxAge > 0 && path.Base(name) != c.options.IndexFile {
+		header.Set("Cache-Control", "public, max-age="+strconv.FormatInt(int64(c.options.MaxAge/time.Second), 10))
+	} else {
+		header.Set("Cache-Control", "no-cache")
+	}
+
+	if c.options.Precompressed {
+		header.Add("Vary", "Accept-Encoding")
+		if strings.Contains(req.Header.Get("Accept-Encoding"), "gzip") {
+			if gzInfo, err := fs.Stat(c.fileSystem, name+".gz"); err == nil && !gzInfo.IsDir() {
+				if contentType == "" {
+					header.Set("Content-Type", "application/octet-stream")
+				}
+				header.Set("Content-Encoding", "gzip")
+				name, info = name+".gz", gzInfo
+			}
+		}
+	}
+
+	header.Set("ETag", "W/\""+strconv.FormatInt(info.Size(), 16)+"-"+strconv.FormatInt(info.ModTime().UnixNano(), 16)+"\"")
+
+	file, err := c.fileSystem.Open(name)
+	if err != nil {
+		http.NotFound(res, req)
+		return
+	}
+	defer file.Close()
+
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		buf, readErr := io.ReadAll(file)
+		if readErr != nil {
+			HttpResponseSender.SendError(res, req, readErr)
+			return
+		}
+		content = bytes.NewReader(buf)
+	}
+	http.ServeContent(res, req, path.Base(name), info.ModTime(), content)
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+)
+
+// StaticRestService is a service that serves static files, i.e. a single-page app,
+// from a directory via HTTP protocol.
+//
+//	Configuration parameters:
+//		- base_route:          base route for remote URI (default: "")
+//		- route:               route to static files (default: "")
+//		- path:                path to the directory with static files (default: "./public")
+//		- options:
+//			- index:           name of the index file in directories (default: "index.html")
+//			- spa:             serve the index file for unknown paths to support client-side routing (default: false)
+//			- max_age:         max age of cached files in seconds, 0 disables caching (default: 0)
+//			- precompressed:   serve precompressed ".gz" files if client accepts gzip (default: false)
+//		- dependencies:
+//			- endpoint:        override for HTTP Endpoint dependency
+//		- connection(s):
+//			- discovery_key:   (optional) a key to retrieve the connection from IDiscovery
+//			- protocol:        connection protocol: http or https
+//			- host:            host name or IP address
+//			- port:            port number
+//			- uri:             resource URI or connection string with all parameters in it
+//
+//	References:
+//		- *:logger:*:*:1.0       (optional)  ILogger components to pass log messages
+//		- *:counters:*:*:1.0     (optional)  ICounters components to pass collected measurements
+//		- *:discovery:*:*:1.0    (optional)  IDiscovery services to resolve connection
+//		- *:endpoint:http:*:1.0  (optional) HttpEndpoint reference
+//
+//	see RestService
+//	see StaticFilesOptions
+//
+//	Example:
+//		service := NewStaticRestService();
+//		service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+//			"route", "admin",
+//			"path", "./admin/dist",
+//			"options.spa", true,
+//			"connection.protocol", "http",
+//			"connection.host", "localhost",
+//			"connection.port", 8080,
+//		));
+//
+//		opnErr := service.Open(context.Background(), "123")
+//		if opnErr == nil {
+//			fmt.Println("The admin app is accessible at http://+:8080/admin");
+//		}
+type StaticRestService struct {
+	*RestService
+	route   string
+	path    string
+	options *StaticFilesOptions
+}
+
+// NewStaticRestService creates a new instance of this service.
+func NewStaticRestService() *StaticRestService {
+	c := &StaticRestService{}
+	c.RestService = InheritRestService(c)
+	c.route = ""
+	c.path = "./public"
+	c.options = NewStaticFilesOptions()
+	return c
+}
+
+// Configure component by passing configuration parameters.
+//
+//	Parameters:
+//		- ctx context.Context
+//		- config configuration parameters to be set.
+func (c *StaticRestService) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.RestService.Configure(ctx, config)
+	c.route = config.GetAsStringWithDefault("route", c.route)
+	c.path = config.GetAsStringWithDefault("path", c.path)
+	c.options = NewStaticFilesOptionsFromConfig(config.GetSection("options"))
+}
+
+// Register all service routes in HTTP endpoint.
+func (c *StaticRestService) Register() {
+	c.RegisterStaticDir(c.route, c.path, c.options)
+}
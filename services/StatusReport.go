@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	crefer "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	crun "github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	cinfo "github.com/pip-services3-gox/pip-services3-components-gox/info"
+)
+
+// StatusReport is a status of a microservice returned by StatusRestService and StatusOperations.
+type StatusReport struct {
+	// Id is a unique container id (usually hostname).
+	Id string `json:"id"`
+	// Name is a container name.
+	Name string `json:"name"`
+	// Description is a container description.
+	Description string `json:"description"`
+	// StartTime is a time when the container was started.
+	StartTime string `json:"start_time"`
+	// CurrentTime is a current time in UTC.
+	CurrentTime string `json:"current_time"`
+	// Uptime is a duration since the container start time in milliseconds.
+	Uptime int64 `json:"uptime"`
+	// Properties are additional container properties.
+	Properties map[string]string `json:"properties"`
+	// Components are locators of components registered in the container.
+	Components []string `json:"components"`
+	// ComponentStates are types and open states of registered components.
+	ComponentStates []*ComponentStatus `json:"component_states"`
+	// Endpoints are HTTP endpoints with their bind addresses and route counts.
+	Endpoints []*EndpointStatus `json:"endpoints"`
+	// Runtime contains Go runtime statistics.
+	Runtime *RuntimeStatus `json:"runtime"`
+	// Build contains build information of the binary, nil when it is not available.
+	Build *BuildStatus `json:"build,omitempty"`
+}
+
+// ComponentStatus is a status of a component registered in the container.
+type ComponentStatus struct {
+	// Locator is a component locator, usually a descriptor.
+	Locator string `json:"locator"`
+	// Type is a Go type of the component.
+	Type string `json:"type"`
+	// Open is an open state of crun.IOpenable components, nil for other components.
+	Open *bool `json:"open,omitempty"`
+}
+
+// EndpointStatus is a status of an HTTP endpoint.
+type EndpointStatus struct {
+	// Address is an address the endpoint listens on.
+	Address string `json:"address"`
+	// Uri is a resolved URI of the endpoint.
+	Uri string `json:"uri"`
+	// Open is true when the endpoint is open.
+	Open bool `json:"open"`
+	// Routes is a number of registered routes.
+	Routes int `json:"routes"`
+}
+
+// RuntimeStatus contains Go runtime statistics.
+type RuntimeStatus struct {
+	GoVersion  string `json:"go_version"`
+	Cpus       int    `json:"cpus"`
+	Goroutines int    `json:"goroutines"`
+	// HeapAlloc is a size of allocated heap objects in bytes.
+	HeapAlloc uint64 `json:"heap_alloc"`
+	// HeapSys is a size of heap memory obtained from the OS in bytes.
+	HeapSys     uint64 `json:"heap_sys"`
+	HeapObjects uint64 `json:"heap_objects"`
+	GcCount     uint32 `json:"gc_count"`
+	// GcPauseTotal is a total time of GC pauses in milliseconds.
+	GcPauseTotal float64 `json:"gc_pause_total"`
+	// GcPauseLast is a time of the last GC pause in milliseconds.
+	GcPauseLast float64 `json:"gc_pause_last"`
+}
+
+// BuildStatus contains build information of the binary.
+type BuildStatus struct {
+	// Path is a main package path.
+	Path string `json:"path"`
+	// Version is a version of the main module.
+	Version string `json:"version"`
+	// GoVersion is a Go version used to build the binary.
+	GoVersion string `json:"go_version"`
+	// Settings are build settings, i.e. "vcs.revision".
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+// statusReporter composes status reports for StatusRestService and StatusOperations.
+type statusReporter struct {
+	startTime   time.Time
+	references  crefer.IReferences
+	contextInfo *cinfo.ContextInfo
+}
+
+func newStatusReporter() *statusReporter {
+	return &statusReporter{startTime: time.Now()}
+}
+
+func (c *statusReporter) setReferences(ctx context.Context, resolver *crefer.DependencyResolver,
+	references crefer.IReferences) {
+
+	c.references = references
+	depRes := resolver.GetOneOptional("context-info")
+	if depRes != nil {
+		if ctxInfo, ok := depRes.(*cinfo.ContextInfo); ok {
+			c.contextInfo = ctxInfo
+		}
+	}
+}
+
+// report composes a status report. Endpoints are found in references,
+// local endpoints of services can be added explicitly.
+func (c *statusReporter) report(endpoints ...*HttpEndpoint) *StatusReport {
+	report := &StatusReport{
+		Name:            "Unknown",
+		StartTime:       cconv.StringConverter.ToString(c.startTime),
+		CurrentTime:     cconv.StringConverter.ToString(time.Now()),
+		Uptime:          time.Since(c.startTime).Milliseconds(),
+		Properties:      make(map[string]string),
+		Components:      make([]string, 0),
+		ComponentStates: make([]*ComponentStatus, 0),
+		Endpoints:       make([]*EndpointStatus, 0),
+		Runtime:         newRuntimeStatus(),
+		Build:           newBuildStatus(),
+	}
+
+	if c.contextInfo != nil {
+		report.Id = c.contextInfo.ContextId
+		report.Name = c.contextInfo.Name
+		report.Description = c.contextInfo.Description
+		if c.contextInfo.Properties != nil {
+			report.Properties = c.contextInfo.Properties
+		}
+	}
+
+	if c.references != nil {
+		for _, locator := range c.references.GetAllLocators() {
+			report.Components = append(report.Components, cconv.StringConverter.ToString(locator))
+		}
+		report.ComponentStates = newComponentStates(c.references)
+		for _, component := range c.references.GetAll() {
+			if endpoint, ok := component.(*HttpEndpoint); ok {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+
+	reported := make(map[*HttpEndpoint]bool)
+	for _, endpoint := range endpoints {
+		if endpoint == nil || reported[endpoint] {
+			continue
+		}
+		reported[endpoint] = true
+		report.Endpoints = append(report.Endpoints, &EndpointStatus{
+			Address: endpoint.GetAddress(),
+			Uri:     endpoint.GetUri(),
+			Open:    endpoint.IsOpen(),
+			Routes:  endpoint.GetRouteCount(),
+		})
+	}
+
+	return report
+}
+
+// newComponentStates describes types and open states of referenced components.
+func newComponentStates(references crefer.IReferences) []*ComponentStatus {
+	states := make([]*ComponentStatus, 0)
+	for _, reference := range findReferencedComponents(references) {
+		state := &ComponentStatus{
+			Locator: reference.name,
+			Type:    fmt.Sprintf("%T", reference.component),
+		}
+		if openable, ok := reference.component.(crun.IOpenable); ok {
+			open := openable.IsOpen()
+			state.Open = &open
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+// referencedComponent is a referenced component with its locator.
+type referencedComponent struct {
+	name      string
+	component any
+}
+
+// findReferencedComponents gets referenced components with their locators.
+// Components are found by each locator, as lists of locators and components
+// are taken separately and can change between the calls.
+func findReferencedComponents(references crefer.IReferences) []*referencedComponent {
+	result := make([]*referencedComponent, 0)
+	found := make(map[string]bool)
+	for _, locator := range references.GetAllLocators() {
+		name := cconv.StringConverter.ToString(locator)
+		if locator == nil || found[name] {
+			continue
+		}
+		found[name] = true
+		components, _ := references.Find(locator, false)
+		for _, component := range components {
+			result = append(result, &referencedComponent{name: name, component: component})
+		}
+	}
+	return result
+}
+
+// runtimeStatsTimeout is a time to reuse memory statistics, as reading them stops the world.
+const runtimeStatsTimeout = time.Second
+
+var runtimeStats struct {
+	lock  sync.Mutex
+	time  time.Time
+	stats runtime.MemStats
+}
+
+// readMemStats reads memory statistics at most once per runtimeStatsTimeout.
+func readMemStats() runtime.MemStats {
+	runtimeStats.lock.Lock()
+	defer runtimeStats.lock.Unlock()
+	if time.Since(runtimeStats.time) >= runtimeStatsTimeout {
+		runtime.ReadMemStats(&runtimeStats.stats)
+		runtimeStats.time = time.Now()
+	}
+	return runtimeStats.stats
+}
+
+func newRuntimeStatus() *RuntimeStatus {
+	stats := readMemStats()
+
+	status := &RuntimeStatus{
+		GoVersion:    runtime.Version(),
+		Cpus:         runtime.NumCPU(),
+		Goroutines:   runtime.NumGoroutine(),
+		HeapAlloc:    stats.HeapAlloc,
+		HeapSys:      stats.HeapSys,
+		HeapObjects:  stats.HeapObjects,
+		GcCount:      stats.NumGC,
+		GcPauseTotal: float64(stats.PauseTotalNs) / float64(time.Millisecond),
+	}
+	if stats.NumGC > 0 {
+		status.GcPauseLast = float64(stats.PauseNs[(stats.NumGC+255)%256]) / float64(time.Millisecond)
+	}
+	return status
+}
+
+func newBuildStatus() *BuildStatus {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	status := &BuildStatus{
+		Path:      info.Path,
+		Version:   info.Main.Version,
+		GoVersion: info.GoVersion,
+	}
+	if len(info.Settings) > 0 {
+		status.Settings = make(map[string]string, len(info.Settings))
+		for _, setting := range info.Settings {
+			status.Settings[setting.Key] = setting.Value
+		}
+	}
+	return status
+}
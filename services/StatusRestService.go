@@ -3,12 +3,9 @@ package services
 import (
 	"context"
 	"net/http"
-	"time"
 
 	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
-	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
 	crefer "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
-	cinfo "github.com/pip-services3-gox/pip-services3-components-gox/info"
 )
 
 // StatusRestService is a service that returns microservice status information via HTTP/REST protocol.
@@ -23,6 +20,10 @@ import (
 //			"uptime":        duration since container start time in milliseconds
 //			"properties":    additional container properties (from ContextInfo)
 //			"components":    descriptors of components registered in the container
+//			"component_states": types and open states of registered components
+//			"endpoints":     bind addresses and route counts of HTTP endpoints
+//			"runtime":       Go runtime statistics: goroutines, heap and GC pauses
+//			"build":         build information of the binary (from debug.ReadBuildInfo)
 //		}
 //
 //	Configuration parameters:
@@ -61,17 +62,15 @@ import (
 //		}
 type StatusRestService struct {
 	*RestService
-	startTime   time.Time
-	references2 crefer.IReferences
-	contextInfo *cinfo.ContextInfo
-	route       string
+	reporter *statusReporter
+	route    string
 }
 
 // NewStatusRestService method are creates a new instance of this service.
 func NewStatusRestService() *StatusRestService {
 	c := &StatusRestService{}
 	c.RestService = InheritRestService(c)
-	c.startTime = time.Now()
+	c.reporter = newStatusReporter()
 	c.route = "status"
 	c.DependencyResolver.Put(
 		context.Background(),
@@ -95,15 +94,8 @@ func (c *StatusRestService) Configure(ctx context.Context, config *cconf.ConfigP
 //		- ctx context.Context
 //		- references crefer.IReferences	references to locate the component dependencies.
 func (c *StatusRestService) SetReferences(ctx context.Context, references crefer.IReferences) {
-	c.references2 = references
 	c.RestService.SetReferences(ctx, references)
-
-	depRes := c.DependencyResolver.GetOneOptional("context-info")
-	if depRes != nil {
-		if _val, ok := depRes.(*cinfo.ContextInfo); ok {
-			c.contextInfo = _val
-		}
-	}
+	c.reporter.setReferences(ctx, c.DependencyResolver, references)
 }
 
 // Register method are registers all service routes in HTTP endpoint.
@@ -111,51 +103,16 @@ func (c *StatusRestService) Register() {
 	c.RegisterRoute(http.MethodGet, c.route, nil, c.status)
 }
 
+// GetStatus method are composes the status report of the microservice.
+//	Returns: *StatusReport the status report
+func (c *StatusRestService) GetStatus() *StatusReport {
+	return c.reporter.report(c.Endpoint)
+}
+
 // Handles status requests
 //	Parameters:
 //		- req  *http.Request an HTTP request
 //		- res  http.ResponseWriter  an HTTP response
 func (c *StatusRestService) status(res http.ResponseWriter, req *http.Request) {
-
-	id := ""
-	if c.contextInfo != nil {
-		id = c.contextInfo.ContextId
-	}
-
-	name := "Unknown"
-	if c.contextInfo != nil {
-		name = c.contextInfo.Name
-	}
-
-	description := ""
-	if c.contextInfo != nil {
-		description = c.contextInfo.Description
-	}
-
-	uptime := time.Since(c.startTime)
-
-	properties := make(map[string]string, 0)
-	if c.contextInfo != nil {
-		properties = c.contextInfo.Properties
-	}
-
-	var components []string
-	if c.references2 != nil {
-		for _, locator := range c.references2.GetAllLocators() {
-			components = append(components, cconv.StringConverter.ToString(locator))
-		}
-	}
-
-	status := make(map[string]any)
-
-	status["id"] = id
-	status["name"] = name
-	status["description"] = description
-	status["start_time"] = cconv.StringConverter.ToString(c.startTime)
-	status["current_time"] = cconv.StringConverter.ToString(time.Now())
-	status["uptime"] = uptime
-	status["properties"] = properties
-	status["components"] = components
-
-	c.SendResult(res, req, status, nil)
+	c.SendResult(res, req, c.GetStatus(), nil)
 }
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed swagger-ui/*.js swagger-ui/*.css swagger-ui/*.html swagger-ui/*.png
+var swaggerUiAssets embed.FS
+
+// SwaggerSpec is an OpenAPI document registered in SwaggerRestService.
+type SwaggerSpec struct {
+	// Name is a name of the document in Swagger UI, usually the service base route.
+	Name string `json:"name"`
+	// Url is a route that serves the document.
+	Url string `json:"url"`
+}
+
+// SwaggerRestService is a service that collects OpenAPI documents of REST services
+// and shows them in Swagger UI via HTTP protocol.
+// Swagger UI assets are embedded into the binary, so the UI works without network access.
+//
+//	RestService resolves this service by "*:swagger-service:*:*:1.0" descriptor and registers
+//	its OpenAPI document when "swagger.enable" is set. Documents must be served by the same endpoint
+//	or the same host and port, as the UI loads them by their routes.
+//
+//	The service responds on the following routes:
+//		- /swagger/           Swagger UI with a dropdown to select a service document
+//		- /swagger/specs      JSON list of registered documents: [{ "name": ..., "url": ... }]
+//		- /swagger/spec       all documents merged into one OpenAPI document in YAML
+//
+//	Configuration parameters:
+//		- base_route:          base route for remote URI (default: "")
+//		- route:               route to Swagger UI (default: "swagger")
+//		- options:
+//			- title:           title of the merged document (default: "API")
+//			- version:         version of the merged document (default: "1")
+//		- dependencies:
+//			- endpoint:        override for HTTP Endpoint dependency
+//		- connection(s):
+//			- discovery_key:   (optional) a key to retrieve the connection from IDiscovery
+//			- protocol:        connection protocol: http or https
+//			- host:            host name or IP address
+//			- port:            port number
+//			- uri:             resource URI or connection string with all parameters in it
+//
+//	References:
+//		- *:logger:*:*:1.0       (optional)  ILogger components to pass log messages
+//		- *:counters:*:*:1.0     (optional)  ICounters components to pass collected measurements
+//		- *:discovery:*:*:1.0    (optional)  IDiscovery services to resolve connection
+//		- *:endpoint:http:*:1.0  (optional) HttpEndpoint reference
+//
+//	see RestService
+//	see ISwaggerService
+//
+//	Example:
+//		service := NewSwaggerRestService();
+//		service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+//			"options.title", "My microservice",
+//			"connection.protocol", "http",
+//			"connection.host", "localhost",
+//			"connection.port", 8080,
+//		));
+//
+//		opnErr := service.Open(context.Background(), "123")
+//		if opnErr == nil {
+//			fmt.Println("Swagger UI is accessible at http://+:8080/swagger/");
+//		}
+type SwaggerRestService struct {
+	*RestService
+	route    string
+	title    string
+	version  string
+	specs    map[string]*SwaggerSpec
+	contents map[string]string
+	lock     sync.Mutex
+}
+
+// NewSwaggerRestService creates a new instance of this service.
+func NewSwaggerRestService() *SwaggerRestService {
+	c := &SwaggerRestService{}
+	c.RestService = InheritRestService(c)
+	c.route = "swagger"
+	c.title = "API"
+	c.version = "1"
+	c.specs = make(map[string]*SwaggerSpec)
+	c.contents = make(map[string]string)
+	return c
+}
+
+// Configure component by passing configuration parameters.
+//	Parameters:
+//		- ctx context.Context
+//		- config configuration parameters to be set.
+func (c *SwaggerRestService) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.RestService.Configure(ctx, config)
+	c.route = config.GetAsStringWithDefault("route", c.route)
+	c.title = config.GetAsStringWithDefault("options.title", c.title)
+	c.version = config.GetAsStringWithDefault("options.version", c.version)
+}
+
+// RegisterOpenApiSpec registers an OpenAPI document served by a REST service.
+//	Parameters:
+//		- baseRoute     a base route of the service
+//		- swaggerRoute  a route of the document relative to the base route
+func (c *SwaggerRestService) RegisterOpenApiSpec(baseRoute string, swaggerRoute string) {
+	url := swaggerSpecUrl(baseRoute, swaggerRoute)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	name := strings.Trim(baseRoute, "/")
+	if name == "" {
+		name = "default"
+	}
+	for specUrl, spec := range c.specs {
+		if spec.Name == name && specUrl != url {
+			name = strings.Trim(url, "/")
+			break
+		}
+	}
+	c.specs[url] = &SwaggerSpec{Name: name, Url: url}
+}
+
+// RegisterOpenApiSpecContent registers content of an OpenAPI document to merge it with other documents.
+//	Parameters:
+//		- baseRoute     a base route of the service
+//		- swaggerRoute  a route of the document relative to the base route
+//		- content       the document in YAML or JSON
+func (c *SwaggerRestService) RegisterOpenApiSpecContent(baseRoute string, swaggerRoute string, content string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.contents[swaggerSpecUrl(baseRoute, swaggerRoute)] = content
+}
+
+// GetSpecs gets registered OpenAPI documents sorted by their names.
+//	Returns: []*SwaggerSpec registered documents
+func (c *SwaggerRestService) GetSpecs() []*SwaggerSpec {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	specs := make([]*SwaggerSpec, 0, len(c.specs))
+	for _, spec := range c.specs {
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Name < specs[j].Name })
+	return specs
+}
+
+// GetMergedSpec merges registered OpenAPI documents into one document.
+// Paths, components and tags of the documents are combined, the first definition of a path wins.
+// Documents that can't be parsed are skipped.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId  (optional) transaction id to trace execution through call chain.
+//	Returns: map[string]any the merged document
+func (c *SwaggerRestService) GetMergedSpec(ctx context.Context, correlationId string) map[string]any {
+	c.lock.Lock()
+	urls := make([]string, 0, len(c.contents))
+	for url := range c.contents {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	contents := make([]string, len(urls))
+	for i, url := range urls {
+		contents[i] = c.contents[url]
+	}
+	c.lock.Unlock()
+
+	paths := make(map[string]any)
+	components := make(map[string]any)
+	tags := make([]any, 0)
+	tagNames := make(map[string]bool)
+
+	for i, content := range contents {
+		var doc map[string]any
+		if err := yaml.Unmarshal([]byte(content), &doc); err != nil || doc == nil {
+			c.Logger.Warn(ctx, correlationId, "Skipped OpenAPI document %s that can't be parsed", urls[i])
+			continue
+		}
+
+		if docPaths, ok := doc["paths"].(map[string]any); ok {
+			for path, item := range docPaths {
+				if _, ok := paths[path]; !ok {
+					paths[path] = item
+				}
+			}
+		}
+
+		if docComponents, ok := doc["components"].(map[string]any); ok {
+			for section, values := range docComponents {
+				sectionValues, ok := values.(map[string]any)
+				if !ok {
+					continue
+				}
+				merged, ok := components[section].(map[string]any)
+				if !ok {
+					merged = make(map[string]any)
+					components[section] = merged
+				}
+				for name, value := range sectionValues {
+					if _, ok := merged[name]; !ok {
+						merged[name] = value
+					}
+				}
+			}
+		}
+
+		if docTags, ok := doc["tags"].([]any); ok {
+			for _, tag := range docTags {
+				name := ""
+				if tagMap, ok := tag.(map[string]any); ok {
+					name = fmt.Sprint(tagMap["name"])
+				}
+				if !tagNames[name] {
+					tagNames[name] = true
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+
+	spec := map[string]any{
+		"openapi": "3.0.2",
+		"info": map[string]any{
+			"title":   c.title,
+			"version": c.version,
+		},
+		"paths": paths,
+	}
+	if len(components) > 0 {
+		spec["components"] = components
+	}
+	if len(tags) > 0 {
+		spec["tags"] = tags
+	}
+	return spec
+}
+
+// Register all service routes in HTTP endpoint.
+func (c *SwaggerRestService) Register() {
+	route := strings.TrimSuffix(c.route, "/")
+	uiRoute := c.uiRoute() + "/"
+
+	c.RegisterRoute(http.MethodGet, route, nil, func(res http.ResponseWriter, req *http.Request) {
+		http.Redirect(res, req, uiRoute, http.StatusMovedPermanently)
+	})
+	c.RegisterRoute(http.MethodGet, route+"/", nil, c.ui)
+	c.RegisterRoute(http.MethodGet, route+"/index.html", nil, c.ui)
+	c.RegisterRoute(http.MethodGet, route+"/specs", nil, c.getSpecs)
+	c.RegisterRoute(http.MethodGet, route+"/spec", nil, c.getMergedSpec)
+
+	assets, _ := fs.Sub(swaggerUiAssets, "swagger-ui")
+	options := NewStaticFilesOptions()
+	options.MaxAge = 24 * time.Hour
+	c.RegisterStaticFiles(route, assets, options)
+}
+
+// Handles requests for the list of documents
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *SwaggerRestService) getSpecs(res http.ResponseWriter, req *http.Request) {
+	c.SendResult(res, req, c.GetSpecs(), nil)
+}
+
+// Handles requests for the merged document
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *SwaggerRestService) getMergedSpec(res http.ResponseWriter, req *http.Request) {
+	content, err := yaml.Marshal(c.GetMergedSpec(req.Context(), c.GetCorrelationId(req)))
+	if err != nil {
+		c.SendError(res, req, err)
+		return
+	}
+	res.Header().Set("Content-Type", "application/x-yaml")
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.Write(content)
+}
+
+// Handles requests for Swagger UI page
+//	Parameters:
+//		- req  *http.Request an HTTP request
+//		- res  http.ResponseWriter  an HTTP response
+func (c *SwaggerRestService) ui(res http.ResponseWriter, req *http.Request) {
+	specs := c.GetSpecs()
+	urls := make([]*SwaggerSpec, 0, len(specs)+1)
+	if len(specs) > 1 {
+		urls = append(urls, &SwaggerSpec{Name: "all", Url: c.uiRoute() + "/spec"})
+	}
+	urls = append(urls, specs...)
+
+	// JSON encoder escapes HTML characters, so the list is safe to embed into the script
+	urlsJson, _ := json.Marshal(urls)
+	page := fmt.Sprintf(swaggerUiPage, urlsJson)
+
+	res.Header().Set("Content-Type", "text/html; charset=utf-8")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.Write([]byte(page))
+}
+
+// uiRoute gets the absolute route of Swagger UI without trailing slash.
+func (c *SwaggerRestService) uiRoute() string {
+	return swaggerSpecUrl(c.versionedBaseRoute(), c.route)
+}
+
+func swaggerSpecUrl(baseRoute string, swaggerRoute string) string {
+	url := strings.Trim(swaggerRoute, "/")
+	if baseRoute = strings.Trim(baseRoute, "/"); baseRoute != "" {
+		url = baseRoute + "/" + url
+	}
+	return "/" + url
+}
+
+const swaggerUiPage = `<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta charset="UTF-8">
+    <title>Swagger UI</title>
+    <link rel="stylesheet" type="text/css" href="./swagger-ui.css" />
+    <link rel="icon" type="image/png" href="./favicon-32x32.png" sizes="32x32" />
+    <link rel="icon" type="image/png" href="./favicon-16x16.png" sizes="16x16" />
+    <style>
+      html { box-sizing: border-box; overflow-y: scroll; }
+      *, *:before, *:after { box-sizing: inherit; }
+      body { margin: 0; background: #fafafa; }
+    </style>
+  </head>
+  <body>
+    <div id="swagger-ui"></div>
+    <script src="./swagger-ui-bundle.js" charset="UTF-8"></script>
+    <script src="./swagger-ui-standalone-preset.js" charset="UTF-8"></script>
+    <script>
+      window.onload = function() {
+        window.ui = SwaggerUIBundle({
+          urls: %s,
+          dom_id: '#swagger-ui',
+          deepLinking: true,
+          presets: [
+            SwaggerUIBundle.presets.apis,
+            SwaggerUIStandalonePreset
+          ],
+          plugins: [
+            SwaggerUIBundle.plugins.DownloadUrl
+          ],
+          layout: "StandaloneLayout"
+        });
+      };
+    </script>
+  </body>
+</html>
+`
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+)
+
+// TraceContext represents W3C Trace Context of a call (https://www.w3.org/TR/trace-context/).
+// It is passed between services in "traceparent" and "tracestate" headers
+// to link spans of the same distributed trace.
+type TraceContext struct {
+	// TraceId is 32 hex characters id of the whole trace.
+	TraceId string
+	// ParentId is 16 hex characters id of the caller span. It is empty for root spans.
+	ParentId string
+	// SpanId is 16 hex characters id of the current span.
+	SpanId string
+	// Flags are trace flags in hex, "01" means the trace is sampled.
+	Flags string
+	// State is vendor-specific trace state passed as is.
+	State string
+}
+
+// NewTraceContext creates a root trace context with new trace and span ids.
+//
+//	Returns: *TraceContext a new trace context
+func NewTraceContext() *TraceContext {
+	return &TraceContext{
+		TraceId: newTraceId(16),
+		SpanId:  newTraceId(8),
+		Flags:   "01",
+	}
+}
+
+// ParseTraceContext parses "traceparent" and "tracestate" header values.
+// The parsed span id becomes the parent of a newly generated span id.
+//
+//	Parameters:
+//		- traceParent string a value of "traceparent" header
+//		- traceState  string a value of "tracestate" header
+//	Returns: *TraceContext parsed trace context and true or nil and false if the value is invalid.
+func ParseTraceContext(traceParent string, traceState string) (*TraceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(traceParent), "-")
+	if len(parts) < 4 {
+		return nil, false
+	}
+	version, traceId, parentId, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isTraceHex(version, 2) || version == "ff" || version == "00" && len(parts) != 4 {
+		return nil, false
+	}
+	if !isTraceHex(traceId, 32) || traceId == strings.Repeat("0", 32) {
+		return nil, false
+	}
+	if !isTraceHex(parentId, 16) || parentId == strings.Repeat("0", 16) {
+		return nil, false
+	}
+	if !isTraceHex(flags, 2) {
+		return nil, false
+	}
+
+	return &TraceContext{
+		TraceId:  traceId,
+		ParentId: parentId,
+		SpanId:   newTraceId(8),
+		Flags:    flags,
+		State:    strings.TrimSpace(traceState),
+	}, true
+}
+
+// NewChild creates a trace context for a child span within the same trace.
+//
+//	Returns: *TraceContext a child trace context
+func (c *TraceContext) NewChild() *TraceContext {
+	return &TraceContext{
+		TraceId:  c.TraceId,
+		ParentId: c.SpanId,
+		SpanId:   newTraceId(8),
+		Flags:    c.Flags,
+		State:    c.State,
+	}
+}
+
+// TraceParent formats the current span as a value of "traceparent" header.
+func (c *TraceContext) TraceParent() string {
+	return "00-" + c.TraceId + "-" + c.SpanId + "-" + c.Flags
+}
+
+// Inject sets "traceparent" and "tracestate" headers of an outgoing request.
+//
+//	Parameters:
+//		- header http.Header request headers
+func (c *TraceContext) Inject(header http.Header) {
+	header.Set(TraceParentHeader, c.TraceParent())
+	if c.State != "" {
+		header.Set(TraceStateHeader, c.State)
+	}
+}
+
+// GetTraceContext retrieves a trace context stored in the context.
+//
+//	Parameters:
+//		- ctx context.Context
+//	Returns: *TraceContext a trace context and true or nil and false if it wasn't set.
+func GetTraceContext(ctx context.Context) (*TraceContext, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	traceContext, ok := ctx.Value(PipTraceContext).(*TraceContext)
+	return traceContext, ok && traceContext != nil
+}
+
+// WithTraceContext returns a copy of the context with the trace context stored in it.
+//
+//	Parameters:
+//		- ctx          context.Context
+//		- traceContext *TraceContext a trace context to store
+//	Returns: context.Context a new context
+func WithTraceContext(ctx context.Context, traceContext *TraceContext) context.Context {
+	return context.WithValue(ctx, PipTraceContext, traceContext)
+}
+
+func newTraceId(size int) string {
+	buf := make([]byte, size)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func isTraceHex(value string, size int) bool {
+	if len(value) != size {
+		return false
+	}
+	for _, ch := range value {
+		if !(ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'f') {
+			return false
+		}
+	}
+	return true
+}
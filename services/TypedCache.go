@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	ccache "github.com/pip-services3-gox/pip-services3-components-gox/cache"
+)
+
+// TypedCache adapts a cache of any values, i.e. created by DefaultCacheFactory,
+// to a cache of typed values. Values of other types, i.e. decoded by distributed caches
+// into generic maps, are converted to the type through JSON.
+type TypedCache[T any] struct {
+	cache ccache.ICache[any]
+}
+
+// NewTypedCache creates a new typed cache over the cache of any values.
+//
+//	Parameters:
+//		- cache ccache.ICache[any] a cache to keep values
+//	Returns: *TypedCache[T]
+func NewTypedCache[T any](cache ccache.ICache[any]) *TypedCache[T] {
+	return &TypedCache[T]{cache: cache}
+}
+
+// Retrieve gets a value from the cache by its key.
+// It returns zero value if the value is not found, has expired or can't be converted to the type.
+func (c *TypedCache[T]) Retrieve(ctx context.Context, correlationId string, key string) (T, error) {
+	value, err := c.cache.Retrieve(ctx, correlationId, key)
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+	return convertCacheValue[T](value), nil
+}
+
+// Store saves a value in the cache for the timeout in milliseconds.
+func (c *TypedCache[T]) Store(ctx context.Context, correlationId string, key string, value T, timeout int64) (T, error) {
+	result, err := c.cache.Store(ctx, correlationId, key, value, timeout)
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+	return convertCacheValue[T](result), nil
+}
+
+// Remove removes a value from the cache by its key.
+func (c *TypedCache[T]) Remove(ctx context.Context, correlationId string, key string) error {
+	return c.cache.Remove(ctx, correlationId, key)
+}
+
+// Contains checks if the cache contains the key.
+func (c *TypedCache[T]) Contains(ctx context.Context, correlationId string, key string) bool {
+	return c.cache.Contains(ctx, correlationId, key)
+}
+
+func convertCacheValue[T any](value any) T {
+	var result T
+	if value == nil {
+		return result
+	}
+	if typed, ok := value.(T); ok {
+		return typed
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return result
+	}
+	if err = json.Unmarshal(data, &result); err != nil {
+		var empty T
+		return empty
+	}
+	return result
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cvalid "github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+)
+
+// RegisterTypedRoute registers a route with a typed action in the service.
+// The request is bound to TReq with BindRequest, validated by the schema, and the action is
+// instrumented as "<base_route>.<method>_<route>". The result is sent as 201 for POST,
+// as a deleted result for DELETE and as a regular result for other methods. Errors are sent
+// with HttpResponseSender.SendError.
+//
+//	Parameters:
+//		- service       *RestService a service to register the route in
+//		- method        string HTTP method: "get", "head", "post", "put", "delete"
+//		- route         string a command route. Base route will be added to this route
+//		- schema        *cvalid.Schema a validation schema to validate received parameters.
+//		- action        an action function that is called when operation is invoked.
+//
+//	Example:
+//		type GetDummyRequest struct {
+//			Id string `path:"dummy_id"`
+//		}
+//
+//		services.RegisterTypedRoute(c.RestService, http.MethodGet, "/dummies/{dummy_id}", nil,
+//			func(ctx context.Context, correlationId string, req GetDummyRequest) (*Dummy, error) {
+//				return c.controller.GetOneById(ctx, correlationId, req.Id)
+//			})
+func RegisterTypedRoute[TReq any, TRes any](service *RestService, method string, route string, schema *cvalid.Schema,
+	action func(ctx context.Context, correlationId string, req TReq) (TRes, error)) {
+
+	service.RegisterRoute(method, route, schema, newTypedRouteHandler(service, method, route, action))
+}
+
+// RegisterTypedRouteWithAuth registers a route with a typed action and authorization in the service.
+// See RegisterTypedRoute for details on binding and sending results.
+//
+//	Parameters:
+//		- service       *RestService a service to register the route in
+//		- method        string HTTP method: "get", "head", "post", "put", "delete"
+//		- route         string a command route. Base route will be added to this route
+//		- schema        *cvalid.Schema a validation schema to validate received parameters.
+//		- authorize     an authorization interceptor
+//		- action        an action function that is called when operation is invoked.
+func RegisterTypedRouteWithAuth[TReq any, TRes any](service *RestService, method string, route string, schema *cvalid.Schema,
+	authorize func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc),
+	action func(ctx context.Context, correlationId string, req TReq) (TRes, error)) {
+
+	service.RegisterRouteWithAuth(method, route, schema, authorize,
+		newTypedRouteHandler(service, method, route, action))
+}
+
+func newTypedRouteHandler[TReq any, TRes any](service *RestService, method string, route string,
+	action func(ctx context.Context, correlationId string, req TReq) (TRes, error)) func(res http.ResponseWriter, req *http.Request) {
+
+	name := typedRouteName(method, route)
+
+	return func(res http.ResponseWriter, req *http.Request) {
+		correlationId := service.GetCorrelationId(req)
+
+		var request TReq
+		if err := BindRequest(req, &request); err != nil {
+			service.SendError(res, req, cerr.NewBadRequestError(correlationId, "BIND_FAILED",
+				"Failed to bind request parameters").WithCause(err))
+			return
+		}
+
+		timing := service.Instrument(req.Context(), correlationId, service.BaseRoute+"."+name)
+		result, err := action(req.Context(), correlationId, request)
+		timing.EndTiming(req.Context(), err)
+
+		// Typed nil pointers, maps and slices are sent as empty results
+		var value any = result
+		if isNilValue(value) {
+			value = nil
+		}
+
+		switch strings.ToUpper(method) {
+		case http.MethodPost:
+			service.SendCreatedResult(res, req, value, err)
+		case http.MethodDelete:
+			service.SendDeletedResult(res, req, value, err)
+		default:
+			service.SendResult(res, req, value, err)
+		}
+	}
+}
+
+// typedRouteName composes an operation name from a method and route,
+// i.e. "get_dummies_dummy_id" for GET "/dummies/{dummy_id}".
+func typedRouteName(method string, route string) string {
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case '{', '}':
+			return -1
+		case '/', '-', '.', ':':
+			return '_'
+		}
+		return r
+	}, strings.Trim(route, "/"))
+	if name == "" {
+		return strings.ToLower(method)
+	}
+	return strings.ToLower(method) + "_" + name
+}
+
+func isNilValue(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	}
+	return false
+}
+
+// BindRequest binds request parameters to a target struct using field tags:
+//   - `path:"name"`  takes a value from route variables
+//   - `query:"name"` takes a value from query parameters, slices receive repeated values or a comma list
+//   - `body:""`      receives the decoded request body
+//
+// When no field is tagged with `body` the whole body is decoded into the target,
+// so its fields are matched by the serializer (i.e. json tags). Path and query values are converted
+// to field types: strings, booleans, integers, floats, time.Time, pointers and slices of them.
+// Targets that are not structs receive the decoded body.
+//
+//	Parameters:
+//		- req    *http.Request a HTTP request object.
+//		- target any a pointer to the target value
+//	Returns: error a conversion or decoding error
+func BindRequest(req *http.Request, target any) error {
+	ptr := reflect.ValueOf(target)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return fmt.Errorf("target must be a non-nil pointer, got %T", target)
+	}
+	value := ptr.Elem()
+	// Allocate pointer request types, i.e. *MyRequest
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+
+	data, err := GetRequestBody(req)
+	if err != nil {
+		return err
+	}
+
+	if value.Kind() != reflect.Struct {
+		if len(data) == 0 {
+			return nil
+		}
+		return decodeRequestBody(req, value.Addr().Interface())
+	}
+
+	binder := &requestBinder{
+		req:   req,
+		vars:  mux.Vars(req),
+		query: req.URL.Query(),
+	}
+	if len(data) > 0 && !hasBodyField(value.Type()) {
+		if err := decodeRequestBody(req, value.Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	return binder.bindStruct(value, len(data) > 0)
+}
+
+type requestBinder struct {
+	req   *http.Request
+	vars  map[string]string
+	query map[string][]string
+}
+
+func (c *requestBinder) bindStruct(value reflect.Value, hasBody bool) error {
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldValue := value.Field(i)
+
+		if name, ok := field.Tag.Lookup("path"); ok {
+			if raw, ok := c.vars[name]; ok {
+				values := []string{raw}
+				if isArrayField(fieldValue) {
+					values = splitParamValues(values)
+				}
+				if err := setFieldValues(fieldValue, values); err != nil {
+					return fmt.Errorf("path parameter %s: %w", name, err)
+				}
+			}
+			continue
+		}
+		if name, ok := field.Tag.Lookup("query"); ok {
+			if raw, ok := c.query[name]; ok && len(raw) > 0 {
+				if isArrayField(fieldValue) {
+					raw = splitQueryValues(raw)
+				}
+				if err := setFieldValues(fieldValue, raw); err != nil {
+					return fmt.Errorf("query parameter %s: %w", name, err)
+				}
+			}
+			continue
+		}
+		if _, ok := field.Tag.Lookup("body"); ok {
+			if hasBody {
+				if err := decodeRequestBody(c.req, fieldValue.Addr().Interface()); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		// Parameters of embedded structs are promoted
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := c.bindStruct(fieldValue, hasBody); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func hasBodyField(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if _, ok := field.Tag.Lookup("body"); ok {
+			return true
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && hasBodyField(field.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// isArrayField checks if the field receives all values of a parameter.
+func isArrayField(field reflect.Value) bool {
+	return field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8
+}
+
+// setFieldValues sets values to an array field or the first value to other fields.
+func setFieldValues(field reflect.Value, raw []string) error {
+	if isArrayField(field) {
+		items := reflect.MakeSlice(field.Type(), len(raw), len(raw))
+		for i, item := range raw {
+			if err := setFieldValue(items.Index(i), item); err != nil {
+				return err
+			}
+		}
+		field.Set(items)
+		return nil
+	}
+	return setFieldValue(field, raw[0])
+}
+
+func setFieldValue(field reflect.Value, raw string) error {
+	if field.Kind() == reflect.Ptr {
+		value := reflect.New(field.Type().Elem())
+		if err := setFieldValue(value.Elem(), raw); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+
+	if field.Type() == timeType {
+		value, ok := cconv.DateTimeConverter.ToNullableDateTime(raw)
+		if !ok {
+			return fmt.Errorf("cannot convert %q to time", raw)
+		}
+		field.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		value, ok := cconv.BooleanConverter.ToNullableBoolean(raw)
+		if !ok {
+			return fmt.Errorf("cannot convert %q to boolean", raw)
+		}
+		field.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, ok := cconv.LongConverter.ToNullableLong(raw)
+		if !ok || field.OverflowInt(value) {
+			return fmt.Errorf("cannot convert %q to %s", raw, field.Type())
+		}
+		field.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, ok := cconv.LongConverter.ToNullableLong(raw)
+		if !ok || value < 0 || field.OverflowUint(uint64(value)) {
+			return fmt.Errorf("cannot convert %q to %s", raw, field.Type())
+		}
+		field.SetUint(uint64(value))
+	case reflect.Float32, reflect.Float64:
+		value, ok := cconv.DoubleConverter.ToNullableDouble(raw)
+		if !ok {
+			return fmt.Errorf("cannot convert %q to %s", raw, field.Type())
+		}
+		field.SetFloat(value)
+	default:
+		return fmt.Errorf("unsupported parameter type %s", field.Type())
+	}
+	return nil
+}
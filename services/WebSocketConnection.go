@@ -0,0 +1,169 @@
+package services
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	WebSocketTextMessage   = websocket.TextMessage
+	WebSocketBinaryMessage = websocket.BinaryMessage
+
+	DefaultWebSocketPingInterval = 30 * time.Second
+	webSocketWriteTimeout        = 10 * time.Second
+)
+
+// WebSocketConnection is a WebSocket connection used by HttpEndpoint and RestClient.
+// It limits size of received messages, keeps the connection alive with ping/pong messages
+// and serializes concurrent writes.
+//
+// Control messages (ping, pong and close) are processed while the connection is read,
+// so the owner has to keep reading messages until an error is returned.
+type WebSocketConnection struct {
+	conn         *websocket.Conn
+	writeLock    sync.Mutex
+	closeOnce    sync.Once
+	done         chan struct{}
+	pingInterval time.Duration
+}
+
+// NewWebSocketConnection wraps an opened WebSocket connection.
+//
+//	Parameters:
+//		- conn           *websocket.Conn an opened connection
+//		- maxMessageSize int64          max size of received messages in bytes, 0 disables the limit
+//		- pingInterval   time.Duration  an interval between pings, 0 disables keepalive
+//	Returns: *WebSocketConnection
+func NewWebSocketConnection(conn *websocket.Conn, maxMessageSize int64, pingInterval time.Duration) *WebSocketConnection {
+	c := &WebSocketConnection{
+		conn:         conn,
+		done:         make(chan struct{}),
+		pingInterval: pingInterval,
+	}
+
+	if maxMessageSize > 0 {
+		conn.SetReadLimit(maxMessageSize)
+	}
+
+	if pingInterval > 0 {
+		// The peer is considered dead when pong isn't received within two ping intervals
+		pongWait := 2 * pingInterval
+		_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pongWait))
+		})
+		go c.keepAlive()
+	}
+
+	return c
+}
+
+func (c *WebSocketConnection) keepAlive() {
+	ticker := time.NewTicker(c.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(webSocketWriteTimeout))
+			if err != nil {
+				_ = c.Close()
+				return
+			}
+		}
+	}
+}
+
+// ReadMessage reads the next data message.
+//
+//	Returns: messageType int, data []byte, err error
+//		a message type (WebSocketTextMessage or WebSocketBinaryMessage), the message data
+//		or an error if the connection was closed.
+func (c *WebSocketConnection) ReadMessage() (messageType int, data []byte, err error) {
+	messageType, data, err = c.conn.ReadMessage()
+	if err == nil && c.pingInterval > 0 {
+		_ = c.conn.SetReadDeadline(time.Now().Add(2 * c.pingInterval))
+	}
+	return messageType, data, err
+}
+
+// ReadJson reads the next message and decodes it from JSON.
+//
+//	Parameters:
+//		- target any a pointer to a value to decode the message to
+//	Returns: error
+func (c *WebSocketConnection) ReadJson(target any) error {
+	_, data, err := c.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// WriteMessage writes a data message. It is safe to call from multiple goroutines.
+//
+//	Parameters:
+//		- messageType int    WebSocketTextMessage or WebSocketBinaryMessage
+//		- data        []byte the message data
+//	Returns: error
+func (c *WebSocketConnection) WriteMessage(messageType int, data []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// WriteJson writes the value as a JSON text message.
+//
+//	Parameters:
+//		- value any a value to write
+//	Returns: error
+func (c *WebSocketConnection) WriteJson(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.WriteMessage(WebSocketTextMessage, data)
+}
+
+// RemoteAddr returns the remote network address.
+func (c *WebSocketConnection) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Done returns a channel that is closed when the connection is closed.
+func (c *WebSocketConnection) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close sends the normal closure message and closes the connection.
+//
+//	Returns: error
+func (c *WebSocketConnection) Close() error {
+	return c.CloseWithReason(websocket.CloseNormalClosure, "")
+}
+
+// CloseWithReason sends the close message with the given code and text and closes the connection.
+// Calling it on a closed connection does nothing.
+//
+//	Parameters:
+//		- code int    a close code, i.e. websocket.CloseGoingAway
+//		- text string a close reason
+//	Returns: error
+func (c *WebSocketConnection) CloseWithReason(code int, text string) error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.done)
+		message := websocket.FormatCloseMessage(code, text)
+		_ = c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(time.Second))
+		err = c.conn.Close()
+	})
+	return err
+}
@@ -0,0 +1,54 @@
+package test_clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayParamsRestClient(t *testing.T) {
+	client := clients.NewRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", DummyRestServicePort,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err := client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	query := client.AddArrayParams(nil, "id", "1", 2, "three")
+	params := cdata.NewStringValueMapFromTuples("skip", 5)
+	response, err := client.CallWithQuery(context.Background(), http.MethodGet, "/typed/echo", "", params, query, nil)
+	assert.Nil(t, err)
+
+	result, err := clients.HandleHttpResponse[map[string]any](response, "")
+	assert.Nil(t, err)
+	assert.Equal(t, []any{"1", "2", "three"}, result["ids"])
+	assert.Equal(t, float64(5), result["skip"])
+
+	// Values with commas are sent as they are
+	query = client.AddArrayParams(nil, "id", "a,b", "c")
+	response, err = client.CallWithQuery(context.Background(), http.MethodGet, "/typed/echo", "", nil, query, nil)
+	assert.Nil(t, err)
+
+	result, err = clients.HandleHttpResponse[map[string]any](response, "")
+	assert.Nil(t, err)
+	assert.Equal(t, []any{"a,b", "c"}, result["ids"])
+
+	// Ordinary parameters are sent as they are
+	params = cdata.NewStringValueMapFromTuples("id", "a\x1fb")
+	response, err = client.Call(context.Background(), http.MethodGet, "/typed/echo", "", params, nil)
+	assert.Nil(t, err)
+
+	result, err = clients.HandleHttpResponse[map[string]any](response, "")
+	assert.Nil(t, err)
+	assert.Equal(t, []any{"a\x1fb"}, result["ids"])
+}
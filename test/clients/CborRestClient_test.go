@@ -0,0 +1,49 @@
+package test_clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tlogic "github.com/pip-services3-gox/pip-services3-rpc-gox/test/logic"
+	test_services "github.com/pip-services3-gox/pip-services3-rpc-gox/test/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCborRestClient(t *testing.T) {
+	service := test_services.NewDummyRestService()
+	service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", CborRestServicePort,
+	))
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), tlogic.NewDummyController(),
+	))
+	err := service.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer service.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	client := NewDummyRestClient()
+	fixture := NewDummyClientFixture(client)
+
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", CborRestServicePort,
+		"options.content_type", services.CborContentType,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err = client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	assert.Equal(t, services.CborContentType, client.Serializer.ContentType())
+
+	t.Run("CborRestClient.CrudOperations", fixture.TestCrudOperations)
+}
@@ -0,0 +1,94 @@
+package test_clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/clients"
+	tdata "github.com/pip-services3-gox/pip-services3-rpc-gox/test/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventStreamRestClient(t *testing.T) {
+	client := clients.NewRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", DummyRestServicePort,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err := client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := client.CallEventStream(ctx, "/dummies/events", "test_events", nil)
+	assert.Nil(t, err)
+
+	// Server closes stream after 3 events, so the client has to resume it
+	for id := 1; id <= 5; id++ {
+		event, ok := <-events
+		assert.True(t, ok)
+		if !ok {
+			return
+		}
+		assert.Equal(t, "dummy", event.Event)
+		var dummy tdata.Dummy
+		assert.Nil(t, json.Unmarshal([]byte(event.Data), &dummy))
+		assert.Equal(t, strconv.Itoa(id), event.Id)
+		assert.Equal(t, event.Id, dummy.Id)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestEventStreamRestClientStopsOnClientError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		// The first connection sends an event, reconnections are rejected
+		if atomic.AddInt32(&calls, 1) > 1 {
+			res.WriteHeader(http.StatusForbidden)
+			return
+		}
+		res.Header().Set("Content-Type", "text/event-stream")
+		_, _ = res.Write([]byte("retry: 10\nid: 1\ndata: test\n\n"))
+	}))
+	defer server.Close()
+
+	client := clients.NewRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.uri", server.URL,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err := client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := client.CallEventStream(ctx, "/events", "", nil)
+	assert.Nil(t, err)
+
+	event, ok := <-events
+	assert.True(t, ok)
+	assert.Equal(t, "test", event.Data)
+
+	// The stream is closed instead of reconnecting until the context is cancelled
+	_, ok = <-events
+	assert.False(t, ok)
+	assert.Nil(t, ctx.Err())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,56 @@
+package test_clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/clients"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldValidationRestClient(t *testing.T) {
+	client := clients.NewRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", DummyRestServicePort,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err := client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	_, err = client.Call(context.Background(), http.MethodPost, "/dummies", "test_validation", nil,
+		map[string]any{"content": 123})
+	appErr, ok := err.(*cerr.ApplicationError)
+	assert.True(t, ok)
+	if !ok {
+		return
+	}
+	assert.Equal(t, 400, appErr.Status)
+	assert.Equal(t, "INVALID_DATA", appErr.Code)
+
+	// Warnings, like the unexpected correlation_id query parameter, are sent as well
+	paths := make(map[string]*services.FieldValidationError)
+	for _, fieldErr := range services.GetFieldValidationErrors(err) {
+		paths[fieldErr.Path] = fieldErr
+	}
+	assert.Len(t, paths, 3)
+	if assert.Contains(t, paths, "correlation_id") {
+		assert.Equal(t, "query", paths["correlation_id"].Location)
+		assert.Equal(t, "warning", paths["correlation_id"].Type)
+	}
+	if assert.Contains(t, paths, "key") {
+		assert.Equal(t, "body", paths["key"].Location)
+		assert.Equal(t, "VALUE_IS_NULL", paths["key"].Code)
+	}
+	if assert.Contains(t, paths, "content") {
+		assert.Equal(t, "body", paths["content"].Location)
+		assert.Equal(t, "TYPE_MISMATCH", paths["content"].Code)
+	}
+}
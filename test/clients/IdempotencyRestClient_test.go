@@ -0,0 +1,61 @@
+package test_clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/clients"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKeyRestClient(t *testing.T) {
+	keys := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		keys = append(keys, req.Header.Get(services.IdempotencyKeyHeader))
+		// Drop the first connection to make the client retry
+		if len(keys) == 1 {
+			conn, _, err := res.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := clients.NewRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.uri", server.URL,
+		"options.retries", 3,
+		"options.timeout", 100,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err := client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	// All attempts of a POST use the same key
+	_, err = client.Call(context.Background(), http.MethodPost, "/dummies", "", nil, map[string]any{"key": "Key 1"})
+	assert.Nil(t, err)
+	assert.Len(t, keys, 2)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+
+	// Each call gets a new key
+	_, err = client.Call(context.Background(), http.MethodPost, "/dummies", "", nil, map[string]any{"key": "Key 1"})
+	assert.Nil(t, err)
+	assert.Len(t, keys, 3)
+	assert.NotEqual(t, keys[0], keys[2])
+
+	// Other methods are sent without keys
+	_, err = client.Call(context.Background(), http.MethodPut, "/dummies", "", nil, map[string]any{"key": "Key 1"})
+	assert.Nil(t, err)
+	assert.Len(t, keys, 4)
+	assert.Empty(t, keys[3])
+}
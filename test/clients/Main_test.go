@@ -15,6 +15,9 @@ import (
 const (
 	DummyRestServicePort = iota + 4000
 	DummyCommandableHttpServicePort
+	WebSocketRestServicePort
+	CborRestServicePort
+	ProblemDetailsServicePort
 )
 
 func TestMain(m *testing.M) {
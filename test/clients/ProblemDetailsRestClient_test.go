@@ -0,0 +1,71 @@
+package test_clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tlogic "github.com/pip-services3-gox/pip-services3-rpc-gox/test/logic"
+	test_services "github.com/pip-services3-gox/pip-services3-rpc-gox/test/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemDetailsRestClient(t *testing.T) {
+	service := test_services.NewDummyRestService()
+	service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", ProblemDetailsServicePort,
+		"options.error_format", "problem",
+	))
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), tlogic.NewDummyController(),
+	))
+	err := service.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer service.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	client := NewDummyRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", ProblemDetailsServicePort,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err = client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	// Problem details are parsed back into application errors
+	err = client.CheckErrorPropagation(context.Background(), "test_problem")
+	appErr, ok := err.(*cerr.ApplicationError)
+	assert.True(t, ok)
+	if !ok {
+		return
+	}
+	assert.Equal(t, "test_problem", appErr.CorrelationId)
+	assert.Equal(t, 404, appErr.Status)
+	assert.Equal(t, cerr.NotFound, appErr.Category)
+	assert.Equal(t, "NOT_FOUND_TEST", appErr.Code)
+	assert.Equal(t, "Not found error", appErr.Message)
+
+	// Validation results are kept in extensions
+	_, err = client.Call(context.Background(), http.MethodPost, "/dummies", "test_problem", nil,
+		map[string]any{"content": "Content"})
+	assert.NotNil(t, err)
+	fieldErrs := services.GetFieldValidationErrors(err)
+	assert.NotEmpty(t, fieldErrs)
+	for _, fieldErr := range fieldErrs {
+		if fieldErr.Type == "error" {
+			assert.Equal(t, "key", fieldErr.Path)
+			assert.Equal(t, "body", fieldErr.Location)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package test_clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortAndProjectionRestClient(t *testing.T) {
+	client := clients.NewRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", DummyRestServicePort,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err := client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	params := client.AddFilterParams(nil, cdata.NewFilterParamsFromTuples("key", "Key 1"))
+	params = client.AddPagingParams(params, cdata.NewPagingParams(0, 10, false))
+	params = client.AddSortParams(params, cdata.NewSortParams([]cdata.SortField{
+		cdata.NewSortField("name", true),
+		cdata.NewSortField("created", false),
+	}))
+	params = client.AddProjectionParams(params, cdata.NewProjectionParamsFromStrings([]string{"id", "content.text"}))
+
+	response, err := client.Call(context.Background(), http.MethodGet, "/dummies/check/query_params", "", params, nil)
+	assert.Nil(t, err)
+	result, err := clients.HandleHttpResponse[map[string]any](response, "")
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]any{"key": "Key 1"}, result["filter"])
+	assert.Equal(t, []any{
+		map[string]any{"name": "name", "ascending": true},
+		map[string]any{"name": "created", "ascending": false},
+	}, result["sort"])
+	assert.Equal(t, []any{"id", "content.text"}, result["fields"])
+}
+
+func TestTokenizedPagingRestClient(t *testing.T) {
+	client := clients.NewRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", DummyRestServicePort,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err := client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	params := client.AddFilterParams(nil, cdata.NewFilterParamsFromTuples("key", "Key 1"))
+	params = client.AddTokenizedPagingParams(params, cdata.NewTokenizedPagingParams("abc", 20, true))
+
+	response, err := client.Call(context.Background(), http.MethodGet, "/dummies/check/query_params", "", params, nil)
+	assert.Nil(t, err)
+	result, err := clients.HandleHttpResponse[map[string]any](response, "")
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]any{"key": "Key 1"}, result["filter"])
+	assert.Equal(t, map[string]any{"token": "abc", "take": float64(20), "total": true}, result["cursor"])
+}
@@ -0,0 +1,59 @@
+package test_clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/clients"
+	tdata "github.com/pip-services3-gox/pip-services3-rpc-gox/test/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamRestClient(t *testing.T) {
+	client := clients.NewRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", DummyRestServicePort,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err := client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	params := cdata.NewStringValueMapFromTuples("count", 1000)
+	response, err := client.CallStream(context.Background(), http.MethodGet, "/dummies/stream", "test_stream", params, nil)
+	assert.Nil(t, err)
+
+	count := 0
+	err = clients.HandleHttpStream[tdata.Dummy](response, "test_stream", func(item tdata.Dummy) error {
+		assert.Equal(t, "Key", item.Key)
+		count++
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1000, count)
+
+	// Server error after the stream has started
+	params = cdata.NewStringValueMapFromTuples("count", 1000, "fail_at", 500)
+	response, err = client.CallStream(context.Background(), http.MethodGet, "/dummies/stream", "test_stream", params, nil)
+	assert.Nil(t, err)
+
+	count = 0
+	err = clients.HandleHttpStream[tdata.Dummy](response, "test_stream", func(item tdata.Dummy) error {
+		count++
+		return nil
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 500, count)
+	appErr, ok := err.(*cerr.ApplicationError)
+	assert.True(t, ok)
+	if ok {
+		assert.Equal(t, "STREAM_FAILED", appErr.Code)
+	}
+}
@@ -0,0 +1,49 @@
+package test_clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/clients"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceContextRestClient(t *testing.T) {
+	var traceParent string
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		traceParent = req.Header.Get("traceparent")
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := clients.NewRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.uri", server.URL,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err := client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer client.Close(context.Background(), "")
+
+	traceContext := services.NewTraceContext()
+	ctx := services.WithTraceContext(context.Background(), traceContext)
+	_, err = client.Call(ctx, http.MethodGet, "/trace", "", nil, nil)
+	assert.Nil(t, err)
+
+	received, ok := services.ParseTraceContext(traceParent, "")
+	assert.True(t, ok)
+	assert.Equal(t, traceContext.TraceId, received.TraceId)
+	assert.NotEqual(t, traceContext.SpanId, received.ParentId)
+
+	// Start a new trace when context doesn't have one
+	_, err = client.Call(context.Background(), http.MethodGet, "/trace", "", nil, nil)
+	assert.Nil(t, err)
+	received, ok = services.ParseTraceContext(traceParent, "")
+	assert.True(t, ok)
+	assert.NotEqual(t, traceContext.TraceId, received.TraceId)
+}
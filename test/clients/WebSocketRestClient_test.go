@@ -0,0 +1,99 @@
+package test_clients
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/clients"
+	tdata "github.com/pip-services3-gox/pip-services3-rpc-gox/test/data"
+	tlogic "github.com/pip-services3-gox/pip-services3-rpc-gox/test/logic"
+	test_services "github.com/pip-services3-gox/pip-services3-rpc-gox/test/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWebSocketTestClient(t *testing.T, port int) *clients.RestClient {
+	client := clients.NewRestClient()
+	client.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", port,
+		"options.request_max_size", 1024,
+	))
+	client.SetReferences(context.Background(), cref.NewEmptyReferences())
+	err := client.Open(context.Background(), "")
+	assert.Nil(t, err)
+	return client
+}
+
+func TestWebSocketRestClient(t *testing.T) {
+	client := newWebSocketTestClient(t, DummyRestServicePort)
+	defer client.Close(context.Background(), "")
+
+	// Reject unauthorized connections before upgrade
+	_, err := client.ConnectWebSocket(context.Background(), "/dummies/ws", "test_ws", nil)
+	appErr, ok := err.(*cerr.ApplicationError)
+	assert.True(t, ok)
+	if ok {
+		assert.Equal(t, 401, appErr.Status)
+		assert.Equal(t, "NOT_SIGNED", appErr.Code)
+	}
+
+	// Exchange messages
+	client.Headers.Put("access_token", "dummy_token")
+	conn, err := client.ConnectWebSocket(context.Background(), "/dummies/ws", "test_ws", nil)
+	assert.Nil(t, err)
+	if conn == nil {
+		return
+	}
+	defer conn.Close()
+
+	dummy := tdata.Dummy{Id: "1", Key: "Key 1", Content: "Content 1"}
+	assert.Nil(t, conn.WriteJson(dummy))
+	var result tdata.Dummy
+	assert.Nil(t, conn.ReadJson(&result))
+	assert.Equal(t, dummy, result)
+
+	// Close connection when message exceeds max request size
+	dummy.Content = strings.Repeat("x", 2*1024*1024)
+	_ = conn.WriteJson(dummy)
+	err = conn.ReadJson(&result)
+	assert.NotNil(t, err)
+}
+
+func TestWebSocketEndpointClose(t *testing.T) {
+	service := test_services.NewDummyRestService()
+	service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", WebSocketRestServicePort,
+	))
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), tlogic.NewDummyController(),
+	))
+	err := service.Open(context.Background(), "")
+	assert.Nil(t, err)
+	time.Sleep(500 * time.Millisecond)
+
+	client := newWebSocketTestClient(t, WebSocketRestServicePort)
+	defer client.Close(context.Background(), "")
+	client.Headers.Put("access_token", "dummy_token")
+	conn, err := client.ConnectWebSocket(context.Background(), "/dummies/ws", "", nil)
+	assert.Nil(t, err)
+	if conn == nil {
+		return
+	}
+
+	// Endpoint closes opened connections
+	err = service.Close(context.Background(), "")
+	assert.Nil(t, err)
+
+	var result tdata.Dummy
+	err = conn.ReadJson(&result)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,124 @@
+package test_services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	cinfo "github.com/pip-services3-gox/pip-services3-components-gox/info"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/auth"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+type aboutUserRegistration struct {
+	endpoint *services.HttpEndpoint
+}
+
+// Register sets a test user like authentication interceptors do
+func (c *aboutUserRegistration) Register() {
+	c.endpoint.RegisterInterceptor("", func(res http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		if req.Header.Get("Authorization") == "" {
+			next(res, req)
+			return
+		}
+		user := *cdata.NewAnyValueMapFromTuples(
+			"user_id", "1",
+			"name", "Test User",
+			"roles", []any{"admin"},
+		)
+		ctx := context.WithValue(req.Context(), auth.PipAuthUser, user)
+		next(res, req.WithContext(ctx))
+	})
+}
+
+func TestAboutRestService(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", AboutRestServicePort,
+	))
+	endpoint.Register(&aboutUserRegistration{endpoint: endpoint})
+
+	contextInfo := cinfo.NewContextInfo()
+	contextInfo.Name = "Test"
+	contextInfo.Description = "This is a test container"
+
+	service := services.NewAboutRestService()
+	service.Configure(context.Background(), cconf.NewEmptyConfigParams())
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services", "endpoint", "http", "default", "1.0"), endpoint,
+		cref.NewDescriptor("pip-services", "context-info", "default", "default", "1.0"), contextInfo,
+	))
+
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d/about", AboutRestServicePort)
+	req, err := http.NewRequest(http.MethodGet, url+"?test=1", nil)
+	assert.Nil(t, err)
+	req.Header.Set("Authorization", "Bearer test")
+	response, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+
+	var about struct {
+		Server map[string]any `json:"server"`
+		Client map[string]any `json:"client"`
+	}
+	assert.Nil(t, json.Unmarshal(body, &about))
+	assert.Equal(t, "Test", about.Server["name"])
+	assert.Equal(t, "http", about.Server["protocol"])
+	assert.Equal(t, url+"?test=1", about.Server["url"])
+	assert.NotNil(t, about.Server["build"])
+	assert.Nil(t, about.Server["tls"])
+	assert.Equal(t, map[string]any{
+		"id":    "1",
+		"name":  "Test User",
+		"roles": []any{"admin"},
+	}, about.Client["user"])
+
+	// Addresses are plain IPv4 and IPv6 addresses
+	addresses, ok := about.Server["addresses"].([]any)
+	assert.True(t, ok)
+	for _, address := range addresses {
+		assert.NotNil(t, net.ParseIP(address.(string)), address)
+	}
+
+	// Anonymous clients have no user
+	response, err = http.Get(url)
+	assert.Nil(t, err)
+	body, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Nil(t, json.Unmarshal(body, &about))
+	assert.Nil(t, about.Client["user"])
+}
+
+func TestAboutOperationsNetworkAddresses(t *testing.T) {
+	addresses := services.NewAboutOperations().GetNetworkAddresses()
+	interfaceAddrs, err := net.InterfaceAddrs()
+	assert.Nil(t, err)
+
+	expected := 0
+	for _, address := range interfaceAddrs {
+		if ipNet, ok := address.(*net.IPNet); ok && ipNet.IP.IsGlobalUnicast() {
+			expected++
+		}
+	}
+	assert.Len(t, addresses, expected)
+}
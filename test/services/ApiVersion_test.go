@@ -0,0 +1,157 @@
+package test_services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tdata "github.com/pip-services3-gox/pip-services3-rpc-gox/test/data"
+	tlogic "github.com/pip-services3-gox/pip-services3-rpc-gox/test/logic"
+	"github.com/stretchr/testify/assert"
+)
+
+func newVersionedDummyRestService(t *testing.T, endpoint *services.HttpEndpoint, key string,
+	config *cconf.ConfigParams) *DummyRestService {
+
+	ctrl := tlogic.NewDummyController()
+	_, err := ctrl.Create(context.Background(), "", *tdata.NewDummy("", key, key))
+	assert.Nil(t, err)
+
+	service := NewDummyRestService()
+	service.Configure(context.Background(), config)
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services", "endpoint", "http", "default", "1.0"), endpoint,
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), ctrl,
+	))
+	return service
+}
+
+func getVersionedDummies(t *testing.T, url string, headers map[string]string) (*http.Response, string) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	assert.Nil(t, err)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	response, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != 200 {
+		return response, ""
+	}
+
+	var page cdata.DataPage[tdata.Dummy]
+	assert.Nil(t, json.Unmarshal(body, &page))
+	if !assert.Len(t, page.Data, 1) {
+		return response, ""
+	}
+	return response, page.Data[0].Key
+}
+
+func TestApiVersions(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", ApiVersionServicePort,
+	))
+
+	sunset := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	newVersionedDummyRestService(t, endpoint, "path v1", cconf.NewConfigParamsFromTuples(
+		"base_route", "api",
+		"version", "v1",
+		"options.deprecated", true,
+		"options.sunset_date", sunset,
+		"swagger.enable", true,
+		"openapi_content", "openapi: v1",
+	))
+	newVersionedDummyRestService(t, endpoint, "path v2", cconf.NewConfigParamsFromTuples(
+		"base_route", "api",
+		"version", "v2",
+	))
+	newVersionedDummyRestService(t, endpoint, "header v1", cconf.NewConfigParamsFromTuples(
+		"base_route", "header",
+		"version", "v1",
+		"options.version_selection", "header",
+		"options.version_default", true,
+		"swagger.enable", true,
+		"openapi_content", "openapi: header v1",
+	))
+	newVersionedDummyRestService(t, endpoint, "header v2", cconf.NewConfigParamsFromTuples(
+		"base_route", "header",
+		"version", "v2",
+		"options.version_selection", "header",
+		"swagger.enable", true,
+		"openapi_content", "openapi: header v2",
+	))
+	newVersionedDummyRestService(t, endpoint, "media v1", cconf.NewConfigParamsFromTuples(
+		"base_route", "media",
+		"version", "v1",
+		"options.version_selection", "media_type",
+	))
+	newVersionedDummyRestService(t, endpoint, "media v2", cconf.NewConfigParamsFromTuples(
+		"base_route", "media",
+		"version", "v2",
+		"options.version_selection", "media_type",
+	))
+
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d", ApiVersionServicePort)
+
+	// Versions selected by path
+	response, key := getVersionedDummies(t, url+"/v1/api/dummies", nil)
+	assert.Equal(t, "path v1", key)
+	assert.Equal(t, "true", response.Header.Get(services.DeprecationHeader))
+	assert.Equal(t, "Tue, 01 Jan 2030 00:00:00 GMT", response.Header.Get(services.SunsetHeader))
+
+	response, key = getVersionedDummies(t, url+"/v2/api/dummies", nil)
+	assert.Equal(t, "path v2", key)
+	assert.Empty(t, response.Header.Get(services.DeprecationHeader))
+	assert.Empty(t, response.Header.Get(services.SunsetHeader))
+
+	// Versions selected by header
+	_, key = getVersionedDummies(t, url+"/header/dummies", map[string]string{services.AcceptVersionHeader: "2"})
+	assert.Equal(t, "header v2", key)
+	_, key = getVersionedDummies(t, url+"/header/dummies", map[string]string{services.AcceptVersionHeader: "v1"})
+	assert.Equal(t, "header v1", key)
+	_, key = getVersionedDummies(t, url+"/header/dummies", nil)
+	assert.Equal(t, "header v1", key)
+	response, _ = getVersionedDummies(t, url+"/header/dummies", map[string]string{services.AcceptVersionHeader: "3"})
+	assert.Equal(t, 404, response.StatusCode)
+
+	// Versions selected by media type
+	_, key = getVersionedDummies(t, url+"/media/dummies", map[string]string{"Accept": "application/json; version=2"})
+	assert.Equal(t, "media v2", key)
+	response, key = getVersionedDummies(t, url+"/media/dummies", map[string]string{"Accept": "application/vnd.pip.v1+json"})
+	assert.Equal(t, "media v1", key)
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	response, _ = getVersionedDummies(t, url+"/media/dummies", nil)
+	assert.Equal(t, 404, response.StatusCode)
+
+	// Every version has its own OpenAPI document
+	for route, content := range map[string]string{
+		"/v1/api/swagger":    "openapi: v1",
+		"/header/swagger/v1": "openapi: header v1",
+		"/header/swagger/v2": "openapi: header v2",
+	} {
+		response, err = http.Get(url + route)
+		assert.Nil(t, err)
+		body, _ := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		assert.Equal(t, 200, response.StatusCode, route)
+		assert.Equal(t, content, string(body), route)
+	}
+}
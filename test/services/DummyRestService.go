@@ -2,9 +2,8 @@ package test_services
 
 import (
 	"context"
-	"encoding/json"
-	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
@@ -101,17 +100,9 @@ func (c *DummyRestService) create(res http.ResponseWriter, req *http.Request) {
 	correlationId := c.GetCorrelationId(req)
 	var dummy tdata.Dummy
 
-	body, bodyErr := ioutil.ReadAll(req.Body)
+	bodyErr := c.DecodeBody(req, &dummy)
 	if bodyErr != nil {
-		err := cerr.NewInternalError(correlationId, "JSON_CNV_ERR", "Cant convert from JSON to Dummy").WithCause(bodyErr)
-		c.SendError(res, req, err)
-		return
-	}
-	defer req.Body.Close()
-	jsonErr := json.Unmarshal(body, &dummy)
-
-	if jsonErr != nil {
-		err := cerr.NewInternalError(correlationId, "JSON_CNV_ERR", "Cant convert from JSON to Dummy").WithCause(jsonErr)
+		err := cerr.NewInternalError(correlationId, "BODY_CNV_ERR", "Cant convert from body to Dummy").WithCause(bodyErr)
 		c.SendError(res, req, err)
 		return
 	}
@@ -129,17 +120,9 @@ func (c *DummyRestService) update(res http.ResponseWriter, req *http.Request) {
 
 	var dummy tdata.Dummy
 
-	body, bodyErr := ioutil.ReadAll(req.Body)
+	bodyErr := c.DecodeBody(req, &dummy)
 	if bodyErr != nil {
-		err := cerr.NewInternalError(correlationId, "JSON_CNV_ERR", "Cant convert from JSON to Dummy").WithCause(bodyErr)
-		c.SendError(res, req, err)
-		return
-	}
-	defer req.Body.Close()
-	jsonErr := json.Unmarshal(body, &dummy)
-
-	if jsonErr != nil {
-		err := cerr.NewInternalError(correlationId, "JSON_CNV_ERR", "Cant convert from JSON to Dummy").WithCause(jsonErr)
+		err := cerr.NewInternalError(correlationId, "BODY_CNV_ERR", "Cant convert from body to Dummy").WithCause(bodyErr)
 		c.SendError(res, req, err)
 		return
 	}
@@ -173,6 +156,16 @@ func (c *DummyRestService) checkCorrelationId(res http.ResponseWriter, req *http
 	c.SendResult(res, req, result, err)
 }
 
+func (c *DummyRestService) checkQueryParams(res http.ResponseWriter, req *http.Request) {
+	c.SendResult(res, req, map[string]any{
+		"filter": c.GetFilterParams(req).Value(),
+		"paging": c.GetPagingParams(req),
+		"cursor": c.GetTokenizedPagingParams(req),
+		"sort":   c.GetSortParams(req),
+		"fields": c.GetProjectionParams(req).Value(),
+	}, nil)
+}
+
 func (c *DummyRestService) checkErrorPropagation(res http.ResponseWriter, req *http.Request) {
 	err := c.controller.CheckErrorPropagation(req.Context(), c.GetCorrelationId(req))
 	c.SendError(res, req, err)
@@ -183,6 +176,128 @@ func (c *DummyRestService) checkGracefulShutdownContext(res http.ResponseWriter,
 	c.SendError(res, req, err)
 }
 
+func (c *DummyRestService) streamEvents(res http.ResponseWriter, req *http.Request) {
+	lastEventId := cconv.IntegerConverter.ToInteger(c.GetLastEventId(req))
+
+	events := make(chan *services.ServerSentEvent)
+	go func() {
+		defer close(events)
+		for id := lastEventId + 1; id <= lastEventId+3; id++ {
+			event := services.NewServerSentEvent(
+				cconv.StringConverter.ToString(id), "dummy",
+				tdata.Dummy{Id: cconv.StringConverter.ToString(id), Key: "Key", Content: "Content"},
+			)
+			event.Retry = 100
+			select {
+			case events <- event:
+			case <-req.Context().Done():
+				return
+			}
+		}
+	}()
+	c.SendEventStream(res, req, events, time.Second)
+}
+
+func (c *DummyRestService) streamDummies(res http.ResponseWriter, req *http.Request) {
+	params := req.URL.Query()
+	count := cconv.IntegerConverter.ToInteger(params.Get("count"))
+	failAt := cconv.IntegerConverter.ToIntegerWithDefault(params.Get("fail_at"), -1)
+
+	items := make(chan any)
+	go func() {
+		defer close(items)
+		for i := 0; i < count; i++ {
+			var item any = tdata.Dummy{Id: cconv.StringConverter.ToString(i), Key: "Key", Content: "Content"}
+			if i == failAt {
+				item = cerr.NewInternalError(c.GetCorrelationId(req), "STREAM_FAILED", "Stream failed")
+			}
+			select {
+			case items <- item:
+			case <-req.Context().Done():
+				return
+			}
+			if i == failAt {
+				return
+			}
+		}
+	}()
+	c.SendStream(res, req, items)
+}
+
+func (c *DummyRestService) authorizeToken(res http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	if req.Header.Get("access_token") != "dummy_token" {
+		c.SendError(res, req, cerr.NewUnauthorizedError(c.GetCorrelationId(req), "NOT_SIGNED", "Access token is invalid"))
+		return
+	}
+	next.ServeHTTP(res, req)
+}
+
+type dummyIdRequest struct {
+	DummyId string `path:"dummy_id"`
+}
+
+type updateDummyRequest struct {
+	DummyId string      `path:"dummy_id"`
+	Dummy   tdata.Dummy `body:""`
+}
+
+type echoParamsRequest struct {
+	Skip  int       `query:"skip" json:"skip"`
+	Ids   []string  `query:"id" json:"ids"`
+	Flag  *bool     `query:"flag" json:"flag"`
+	Since time.Time `query:"since" json:"since"`
+}
+
+func (c *DummyRestService) registerTypedRoutes() {
+	services.RegisterTypedRoute(c.RestService, http.MethodGet, "/typed/dummies/{dummy_id}",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("dummy_id", cconv.String).Schema,
+		func(ctx context.Context, correlationId string, req dummyIdRequest) (tdata.Dummy, error) {
+			return c.controller.GetOneById(ctx, correlationId, req.DummyId)
+		})
+
+	services.RegisterTypedRoute(c.RestService, http.MethodPost, "/typed/dummies",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("body", tdata.NewDummySchema()).Schema,
+		func(ctx context.Context, correlationId string, dummy tdata.Dummy) (tdata.Dummy, error) {
+			return c.controller.Create(ctx, correlationId, dummy)
+		})
+
+	services.RegisterTypedRoute(c.RestService, http.MethodPut, "/typed/dummies/{dummy_id}",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("dummy_id", cconv.String).
+			WithRequiredProperty("body", tdata.NewDummySchema()).Schema,
+		func(ctx context.Context, correlationId string, req updateDummyRequest) (tdata.Dummy, error) {
+			req.Dummy.Id = req.DummyId
+			return c.controller.Update(ctx, correlationId, req.Dummy)
+		})
+
+	services.RegisterTypedRouteWithAuth(c.RestService, http.MethodDelete, "/typed/dummies/{dummy_id}",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("dummy_id", cconv.String).Schema,
+		c.authorizeToken,
+		func(ctx context.Context, correlationId string, req dummyIdRequest) (tdata.Dummy, error) {
+			return c.controller.DeleteById(ctx, correlationId, req.DummyId)
+		})
+
+	services.RegisterTypedRoute(c.RestService, http.MethodGet, "/typed/echo", nil,
+		func(ctx context.Context, correlationId string, req *echoParamsRequest) (*echoParamsRequest, error) {
+			return req, nil
+		})
+}
+
+func (c *DummyRestService) echoWebSocket(req *http.Request, conn *services.WebSocketConnection) {
+	for {
+		var dummy tdata.Dummy
+		if err := conn.ReadJson(&dummy); err != nil {
+			return
+		}
+		if err := conn.WriteJson(dummy); err != nil {
+			return
+		}
+	}
+}
+
 func (c *DummyRestService) Register() {
 	c.RegisterInterceptor("/dummies$", c.incrementNumberOfCalls)
 
@@ -201,6 +316,31 @@ func (c *DummyRestService) Register() {
 		c.checkCorrelationId,
 	)
 
+	c.RegisterRoute(
+		http.MethodGet, "/projected/dummies",
+		nil,
+		services.ProjectResponse(c.getPageByFilter),
+	)
+
+	c.RegisterRoute(
+		http.MethodGet, "/cached/dummies",
+		nil,
+		c.CacheResponse("dummies", 0, c.getPageByFilter),
+	)
+
+	c.RegisterRoute(
+		http.MethodPost, "/cached/dummies",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("body", tdata.NewDummySchema()).Schema,
+		c.InvalidateResponses(c.create, "dummies"),
+	)
+
+	c.RegisterRoute(
+		http.MethodGet, "/dummies/check/query_params",
+		nil,
+		c.checkQueryParams,
+	)
+
 	c.RegisterRoute(
 		http.MethodGet, "/dummies/check/error_propagation",
 		cvalid.NewObjectSchema().Schema,
@@ -213,6 +353,24 @@ func (c *DummyRestService) Register() {
 		c.checkGracefulShutdownContext,
 	)
 
+	c.RegisterRoute(
+		http.MethodGet, "/dummies/events",
+		nil,
+		c.streamEvents,
+	)
+
+	c.RegisterRoute(
+		http.MethodGet, "/dummies/stream",
+		nil,
+		c.streamDummies,
+	)
+
+	c.RegisterWebSocketRouteWithAuth(
+		"/dummies/ws",
+		c.authorizeToken,
+		c.echoWebSocket,
+	)
+
 	c.RegisterRoute(
 		http.MethodGet, "/dummies/{dummy_id}",
 		cvalid.NewObjectSchema().
@@ -227,6 +385,13 @@ func (c *DummyRestService) Register() {
 		c.create,
 	)
 
+	c.RegisterRoute(
+		http.MethodPost, "/idempotent/dummies",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("body", tdata.NewDummySchema()).Schema,
+		c.Idempotent(c.create),
+	)
+
 	c.RegisterRoute(
 		http.MethodPut, "/dummies",
 		cvalid.NewObjectSchema().
@@ -241,6 +406,8 @@ func (c *DummyRestService) Register() {
 		c.deleteById,
 	)
 
+	c.registerTypedRoutes()
+
 	if c.openApiContent != "" {
 		c.RegisterOpenApiSpec(c.openApiContent)
 	}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
@@ -180,3 +181,70 @@ func TestDummyRestService(t *testing.T) {
 	assert.Nil(t, bodyErr)
 	assert.Equal(t, "swagger yaml content from file", (string)(resBody))
 }
+
+func TestDummyRestServiceEvents(t *testing.T) {
+
+	url := fmt.Sprintf("http://localhost:%d", DummyRestServicePort)
+
+	req, reqErr := http.NewRequest(http.MethodGet, url+"/dummies/events", nil)
+	assert.Nil(t, reqErr)
+	req.Header.Set("Last-Event-ID", "10")
+	getResponse, getErr := http.DefaultClient.Do(req)
+	assert.Nil(t, getErr)
+	resBody, bodyErr := ioutil.ReadAll(getResponse.Body)
+	assert.Nil(t, bodyErr)
+	getResponse.Body.Close()
+
+	assert.Equal(t, "text/event-stream", getResponse.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", getResponse.Header.Get("Cache-Control"))
+
+	events := strings.Split(strings.TrimSpace(string(resBody)), "\n\n")
+	assert.Len(t, events, 3)
+	assert.True(t, strings.HasPrefix(events[0], "id: 11\nevent: dummy\nretry: 100\ndata: {"))
+	assert.True(t, strings.HasPrefix(events[2], "id: 13\n"))
+}
+
+func TestDummyRestServiceStream(t *testing.T) {
+
+	url := fmt.Sprintf("http://localhost:%d", DummyRestServicePort)
+
+	req, reqErr := http.NewRequest(http.MethodGet, url+"/dummies/stream?count=250", nil)
+	assert.Nil(t, reqErr)
+	req.Header.Set("Accept", "application/json")
+	getResponse, getErr := http.DefaultClient.Do(req)
+	assert.Nil(t, getErr)
+	resBody, bodyErr := ioutil.ReadAll(getResponse.Body)
+	assert.Nil(t, bodyErr)
+	getResponse.Body.Close()
+
+	assert.Equal(t, "application/json", getResponse.Header.Get("Content-Type"))
+	assert.Equal(t, "", getResponse.Trailer.Get("X-Stream-Error"))
+
+	var dummies []tdata.Dummy
+	assert.Nil(t, json.Unmarshal(resBody, &dummies))
+	assert.Len(t, dummies, 250)
+	assert.Equal(t, "249", dummies[249].Id)
+
+	// Error in the middle of the stream is reported in the trailer
+	req, reqErr = http.NewRequest(http.MethodGet, url+"/dummies/stream?count=250&fail_at=120", nil)
+	assert.Nil(t, reqErr)
+	req.Header.Set("Accept", "application/x-ndjson")
+	getResponse, getErr = http.DefaultClient.Do(req)
+	assert.Nil(t, getErr)
+	resBody, bodyErr = ioutil.ReadAll(getResponse.Body)
+	assert.Nil(t, bodyErr)
+	getResponse.Body.Close()
+
+	assert.Equal(t, "application/x-ndjson", getResponse.Header.Get("Content-Type"))
+	assert.Len(t, strings.Split(strings.TrimSpace(string(resBody)), "\n"), 120)
+	assert.Contains(t, getResponse.Trailer.Get("X-Stream-Error"), "STREAM_FAILED")
+
+	// Streams are not sent in other media types
+	req, reqErr = http.NewRequest(http.MethodGet, url+"/dummies/stream?count=10", nil)
+	assert.Nil(t, reqErr)
+	req.Header.Set("Accept", "application/xml")
+	getResponse, getErr = http.DefaultClient.Do(req)
+	assert.Nil(t, getErr)
+	getResponse.Body.Close()
+	assert.Equal(t, http.StatusNotAcceptable, getResponse.StatusCode)
+}
@@ -0,0 +1,87 @@
+package test_services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cvalid "github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldValidationRegistration struct {
+	endpoint *services.HttpEndpoint
+}
+
+func (c *fieldValidationRegistration) Register() {
+	c.endpoint.RegisterRoute(
+		http.MethodPost, "/items/{item_id}",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("item_id", cvalid.NewSchema().WithRule(cvalid.NewIncludedRule("1", "2"))).
+			WithRequiredProperty("take", cconv.String).
+			WithRequiredProperty("body", cvalid.NewObjectSchema().
+				WithRequiredProperty("name", cconv.String).
+				WithOptionalProperty("count", cconv.Long)).Schema,
+		func(res http.ResponseWriter, req *http.Request) {
+			services.HttpResponseSender.SendEmptyResult(res, req, nil)
+		},
+	)
+}
+
+func TestFieldValidationErrors(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", FieldValidationServicePort,
+	))
+	endpoint.Register(&fieldValidationRegistration{endpoint: endpoint})
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d", FieldValidationServicePort)
+
+	body, _ := json.Marshal(map[string]any{"count": "many"})
+	response, err := http.Post(url+"/items/abc", "application/json", bytes.NewBuffer(body))
+	assert.Nil(t, err)
+	resBody, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 400, response.StatusCode)
+
+	var appErr cerr.ApplicationError
+	assert.Nil(t, json.Unmarshal(resBody, &appErr))
+	assert.Equal(t, "INVALID_DATA", appErr.Code)
+
+	errs := services.GetFieldValidationErrors(&appErr)
+	locations := make(map[string]string)
+	for _, fieldErr := range errs {
+		assert.Equal(t, "error", fieldErr.Type)
+		assert.NotEqual(t, "", fieldErr.Code)
+		assert.NotEqual(t, "", fieldErr.Message)
+		locations[fieldErr.Path] = fieldErr.Location
+	}
+	assert.Equal(t, map[string]string{
+		"item_id": "path",
+		"take":    "query",
+		"name":    "body",
+		"count":   "body",
+	}, locations)
+
+	// Valid request passes
+	body, _ = json.Marshal(map[string]any{"name": "Item", "count": 5})
+	response, err = http.Post(url+"/items/1?take=10", "application/json", bytes.NewBuffer(body))
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, 204, response.StatusCode)
+}
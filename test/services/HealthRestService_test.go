@@ -0,0 +1,200 @@
+package test_services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+type healthCheckComponent struct {
+	err error
+}
+
+func (c *healthCheckComponent) CheckHealth(ctx context.Context, correlationId string) error {
+	return c.err
+}
+
+type openableComponent struct {
+	opened bool
+}
+
+func (c *openableComponent) IsOpen() bool {
+	return c.opened
+}
+
+func (c *openableComponent) Open(ctx context.Context, correlationId string) error {
+	c.opened = true
+	return nil
+}
+
+func (c *openableComponent) Close(ctx context.Context, correlationId string) error {
+	c.opened = false
+	return nil
+}
+
+func getHealthReport(t *testing.T, url string) (int, *services.HealthReport) {
+	response, err := http.Get(url)
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	var report services.HealthReport
+	assert.Nil(t, json.Unmarshal(body, &report))
+	return response.StatusCode, &report
+}
+
+func findHealthCheck(report *services.HealthReport, name string) *services.HealthCheckResult {
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	return nil
+}
+
+func TestHealthRestService(t *testing.T) {
+	check := &healthCheckComponent{}
+	openable := &openableComponent{}
+
+	service := services.NewHealthRestService()
+	service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", HealthRestServicePort,
+		"options.cache_timeout", 0,
+	))
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "check", "default", "default", "1.0"), check,
+		cref.NewDescriptor("pip-services-dummies", "openable", "default", "default", "1.0"), openable,
+		cref.NewDescriptor("pip-services", "health-service", "http", "default", "1.0"), service,
+	))
+	slow := false
+	service.RegisterHealthCheck("slow", 100, func(ctx context.Context, correlationId string) error {
+		if slow {
+			time.Sleep(time.Second)
+		}
+		return nil
+	})
+
+	err := service.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer service.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d/health", HealthRestServicePort)
+
+	// Liveness doesn't depend on checks
+	status, report := getHealthReport(t, url+"/live")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, services.HealthStatusUp, report.Status)
+	assert.Empty(t, report.Checks)
+
+	// Openable components must be opened
+	status, report = getHealthReport(t, url+"/ready")
+	assert.Equal(t, 503, status)
+	assert.Equal(t, services.HealthStatusDown, report.Status)
+	assert.Len(t, report.Checks, 3)
+	result := findHealthCheck(report, "pip-services-dummies:openable:default:default:1.0")
+	if assert.NotNil(t, result) {
+		assert.Equal(t, services.HealthStatusDown, result.Status)
+		assert.Equal(t, "component is not open", result.Error)
+	}
+	result = findHealthCheck(report, "pip-services-dummies:check:default:default:1.0")
+	if assert.NotNil(t, result) {
+		assert.Equal(t, services.HealthStatusUp, result.Status)
+	}
+
+	openable.opened = true
+	status, report = getHealthReport(t, url+"/ready")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, services.HealthStatusUp, report.Status)
+
+	// Failed checks are reported with their errors
+	check.err = errors.New("connection refused")
+	status, report = getHealthReport(t, url+"/ready")
+	assert.Equal(t, 503, status)
+	result = findHealthCheck(report, "pip-services-dummies:check:default:default:1.0")
+	if assert.NotNil(t, result) {
+		assert.Equal(t, "connection refused", result.Error)
+	}
+	check.err = nil
+
+	// Slow checks fail by their timeouts
+	slow = true
+	start := time.Now()
+	status, report = getHealthReport(t, url+"/ready")
+	assert.Equal(t, 503, status)
+	assert.Less(t, time.Since(start), 900*time.Millisecond)
+	result = findHealthCheck(report, "slow")
+	if assert.NotNil(t, result) {
+		assert.Equal(t, services.HealthStatusDown, result.Status)
+		assert.Equal(t, "health check timed out", result.Error)
+	}
+}
+
+func TestHealthRestServiceCache(t *testing.T) {
+	check := &healthCheckComponent{}
+
+	service := services.NewHealthRestService()
+	service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"options.cache_timeout", 60000,
+	))
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "check", "default", "default", "1.0"), check,
+	))
+
+	report := service.CheckHealth(context.Background(), "")
+	assert.Equal(t, services.HealthStatusUp, report.Status)
+
+	// Results are reused until the cache expires
+	check.err = errors.New("connection refused")
+	report = service.CheckHealth(context.Background(), "")
+	assert.Equal(t, services.HealthStatusUp, report.Status)
+}
+
+func TestHealthRestServiceConcurrentChecks(t *testing.T) {
+	service := services.NewHealthRestService()
+	service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"options.cache_timeout", 0,
+	))
+	service.SetReferences(context.Background(), cref.NewEmptyReferences())
+
+	var calls int32
+	service.RegisterHealthCheck("slow", 1000, func(ctx context.Context, correlationId string) error {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-time.After(200 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	// The probe that starts checks is cancelled, but checks are not
+	ctx, cancel := context.WithCancel(context.Background())
+	reports := make(chan *services.HealthReport, 3)
+	go func() { reports <- service.CheckHealth(ctx, "") }()
+	time.Sleep(50 * time.Millisecond)
+	go func() { reports <- service.CheckHealth(context.Background(), "") }()
+	go func() { reports <- service.CheckHealth(context.Background(), "") }()
+	cancel()
+
+	for i := 0; i < 3; i++ {
+		report := <-reports
+		assert.Equal(t, services.HealthStatusUp, report.Status)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -27,3 +27,54 @@ func TestHttpEndpoint(t *testing.T) {
 	assert.False(t, dummies.HasData())
 	assert.Len(t, dummies.Data, 0)
 }
+
+func TestHttpEndpointCorrelationId(t *testing.T) {
+
+	url := fmt.Sprintf("http://localhost:%d", HttpEndpointServicePort)
+
+	// Generate correlation id when it is missing
+	getResponse, getErr := http.Get(url + "/api/v1/dummies/check/correlation_id")
+	assert.Nil(t, getErr)
+	resBody, bodyErr := ioutil.ReadAll(getResponse.Body)
+	assert.Nil(t, bodyErr)
+	getResponse.Body.Close()
+	values := make(map[string]string, 0)
+	jsonErr := json.Unmarshal(resBody, &values)
+	assert.Nil(t, jsonErr)
+	assert.Regexp(t, "^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$", values["correlationId"])
+	assert.Equal(t, values["correlationId"], getResponse.Header.Get("correlation_id"))
+
+	// Use header aliases in configured order
+	req, reqErr := http.NewRequest(http.MethodGet, url+"/api/v1/dummies/check/correlation_id", nil)
+	assert.Nil(t, reqErr)
+	req.Header.Set("correlation_id", "test_cor_id")
+	req.Header.Set("X-Request-Id", "test_request_id")
+	getResponse, getErr = http.DefaultClient.Do(req)
+	assert.Nil(t, getErr)
+	resBody, bodyErr = ioutil.ReadAll(getResponse.Body)
+	assert.Nil(t, bodyErr)
+	getResponse.Body.Close()
+	values = make(map[string]string, 0)
+	jsonErr = json.Unmarshal(resBody, &values)
+	assert.Nil(t, jsonErr)
+	assert.Equal(t, "test_request_id", values["correlationId"])
+	assert.Equal(t, "test_request_id", getResponse.Header.Get("correlation_id"))
+}
+
+func TestHttpEndpointExposedHeaders(t *testing.T) {
+
+	url := fmt.Sprintf("http://localhost:%d", HttpEndpointServicePort)
+
+	req, reqErr := http.NewRequest(http.MethodGet, url+"/api/v1/dummies", nil)
+	assert.Nil(t, reqErr)
+	req.Header.Set("Origin", "http://example.com")
+	getResponse, getErr := http.DefaultClient.Do(req)
+	assert.Nil(t, getErr)
+	getResponse.Body.Close()
+
+	exposedHeaders := getResponse.Header.Get("Access-Control-Expose-Headers")
+	for _, header := range []string{"Correlation_id", "Traceparent", "X-Total-Count", "Link",
+		"Deprecation", "Sunset", "X-Cache", "Idempotent-Replayed", "Retry-After"} {
+		assert.Contains(t, exposedHeaders, header)
+	}
+}
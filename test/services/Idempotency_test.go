@@ -0,0 +1,103 @@
+package test_services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tdata "github.com/pip-services3-gox/pip-services3-rpc-gox/test/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func postIdempotent(t *testing.T, url string, key string, dummy *tdata.Dummy, headers ...string) (*http.Response, []byte) {
+	body, _ := json.Marshal(dummy)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	assert.Nil(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(services.IdempotencyKeyHeader, key)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	response, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	resBody, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	return response, resBody
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	store := services.NewMemoryIdempotencyStore()
+	ctx := context.Background()
+
+	stored, err := store.Retrieve(ctx, "", "key1")
+	assert.Nil(t, err)
+	assert.Nil(t, stored)
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	err = store.Store(ctx, "", "key1", &services.IdempotentResponse{
+		RequestHash: "hash", Status: 201, Header: header, Body: []byte(`{"id":"1"}`),
+	}, 60000)
+	assert.Nil(t, err)
+
+	stored, err = store.Retrieve(ctx, "", "key1")
+	assert.Nil(t, err)
+	assert.NotNil(t, stored)
+	assert.Equal(t, "hash", stored.RequestHash)
+	assert.Equal(t, 201, stored.Status)
+	assert.Equal(t, header, stored.Header)
+	assert.Equal(t, `{"id":"1"}`, string(stored.Body))
+}
+
+func TestDummyRestServiceIdempotency(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/idempotent/dummies", DummyOpenAPIFileRestServicePort)
+	dummy := tdata.NewDummy("", "Idempotent Key", "Idempotent Content")
+
+	// First request is processed
+	response, body1 := postIdempotent(t, url, "key-1", dummy)
+	assert.Equal(t, 201, response.StatusCode)
+	assert.Empty(t, response.Header.Get(services.IdempotentReplayedHeader))
+	var created1 tdata.Dummy
+	assert.Nil(t, json.Unmarshal(body1, &created1))
+	assert.NotEmpty(t, created1.Id)
+
+	// Repeated request is replayed with its own correlation id
+	response, body2 := postIdempotent(t, url, "key-1", dummy, "correlation_id", "replayed_request")
+	assert.Equal(t, 201, response.StatusCode)
+	assert.Equal(t, "true", response.Header.Get(services.IdempotentReplayedHeader))
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	assert.Equal(t, "replayed_request", response.Header.Get("correlation_id"))
+	assert.Equal(t, body1, body2)
+
+	// Keys are not shared by different credentials
+	response, body6 := postIdempotent(t, url, "key-1", dummy, "Authorization", "Bearer other")
+	assert.Equal(t, 201, response.StatusCode)
+	assert.Empty(t, response.Header.Get(services.IdempotentReplayedHeader))
+	var created6 tdata.Dummy
+	assert.Nil(t, json.Unmarshal(body6, &created6))
+	assert.NotEqual(t, created1.Id, created6.Id)
+
+	// The key can't be reused with a different body
+	other := tdata.NewDummy("", "Other Key", "Other Content")
+	response, body3 := postIdempotent(t, url, "key-1", other)
+	assert.Equal(t, 409, response.StatusCode)
+	var appErr cerr.ApplicationError
+	assert.Nil(t, json.Unmarshal(body3, &appErr))
+	assert.Equal(t, "IDEMPOTENCY_KEY_REUSED", appErr.Code)
+
+	// Requests without a key are processed every time
+	_, body4 := postIdempotent(t, url, "", dummy)
+	_, body5 := postIdempotent(t, url, "", dummy)
+	var created4, created5 tdata.Dummy
+	assert.Nil(t, json.Unmarshal(body4, &created4))
+	assert.Nil(t, json.Unmarshal(body5, &created5))
+	assert.NotEqual(t, created4.Id, created5.Id)
+	assert.NotEqual(t, created1.Id, created4.Id)
+}
@@ -26,6 +26,21 @@ const (
 	DummyOpenAPIFileRestServicePort
 	DummyCommandableHttpServicePort
 	DummyCommandableSwaggerHttpServicePort
+	StaticRestServicePort
+	ProblemDetailsServicePort
+	FieldValidationServicePort
+	RequestBodyServicePort
+	QueryParamsServicePort
+	CoercedParamsServicePort
+	PagingHeadersServicePort
+	ResponseCacheServicePort
+	ApiVersionServicePort
+	HealthRestServicePort
+	AboutRestServicePort
+	AdminRestServicePort
+	ProfilingRestServicePort
+	SwaggerRestServicePort
+	PagingRestServicePort
 )
 
 func TestMain(m *testing.M) {
@@ -173,6 +188,8 @@ func BuildTestHttpEndpointService() (*DummyRestService, *services.HttpEndpoint)
 		"connection.port", HttpEndpointServicePort,
 		"cors_headers", "correlation_id, access_token, Accept, Content-Type, Content-Length, X-CSRF-Token",
 		"cors_origins", "*",
+		"options.generate_correlation_id", true,
+		"options.correlation_id_headers", "X-Request-Id, X-Correlation-Id, correlation_id",
 	)
 
 	ctrl := tlogic.NewDummyController()
@@ -0,0 +1,173 @@
+package test_services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tlogic "github.com/pip-services3-gox/pip-services3-rpc-gox/test/logic"
+	"github.com/stretchr/testify/assert"
+)
+
+const pagingHeadersTotal = 25
+
+type pagingHeadersRegistration struct {
+	*services.RestOperations
+	endpoint *services.HttpEndpoint
+}
+
+func (c *pagingHeadersRegistration) Register() {
+	c.endpoint.RegisterRoute(http.MethodGet, "/items", nil,
+		services.AddPagingHeaders(func(res http.ResponseWriter, req *http.Request) {
+			paging := c.GetPagingParams(req)
+			skip := paging.GetSkip(0)
+			take := paging.GetTake(100)
+			items := make([]int64, 0)
+			for i := skip; i < skip+take && i < pagingHeadersTotal; i++ {
+				items = append(items, i)
+			}
+			c.SendResult(res, req, cdata.NewDataPage(items, pagingHeadersTotal), nil)
+		}))
+
+	c.endpoint.RegisterRoute(http.MethodGet, "/cursor", nil,
+		services.AddPagingHeaders(func(res http.ResponseWriter, req *http.Request) {
+			paging := c.GetTokenizedPagingParams(req)
+			start, _ := strconv.Atoi(paging.Token)
+			next := ""
+			if start+int(paging.Take) < pagingHeadersTotal {
+				next = strconv.Itoa(start + int(paging.Take))
+			}
+			c.SendResult(res, req, cdata.NewTokenizedDataPage(next, []int{start}), nil)
+		}))
+
+	c.endpoint.RegisterRoute(http.MethodGet, "/plain", nil,
+		func(res http.ResponseWriter, req *http.Request) {
+			c.SendResult(res, req, cdata.NewDataPage([]int{1}, 1), nil)
+		})
+}
+
+func parseLinks(header string) map[string]string {
+	links := make(map[string]string)
+	for _, link := range strings.Split(header, ", ") {
+		parts := strings.SplitN(link, "; ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		rel := strings.TrimSuffix(strings.TrimPrefix(parts[1], "rel=\""), "\"")
+		links[rel] = strings.TrimSuffix(strings.TrimPrefix(parts[0], "<"), ">")
+	}
+	return links
+}
+
+func TestPagingHeaders(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", PagingHeadersServicePort,
+	))
+	endpoint.Register(&pagingHeadersRegistration{
+		RestOperations: services.NewRestOperations(),
+		endpoint:       endpoint,
+	})
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d", PagingHeadersServicePort)
+
+	// Middle page has all links
+	response, err := http.Get(url + "/items?key=abc&skip=10&take=10")
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, "25", response.Header.Get(services.TotalCountHeader))
+	links := parseLinks(response.Header.Get(services.LinkHeader))
+	assert.Equal(t, url+"/items?key=abc&skip=20&take=10", links["next"])
+	assert.Equal(t, url+"/items?key=abc&skip=0&take=10", links["prev"])
+	assert.Equal(t, url+"/items?key=abc&skip=0&take=10", links["first"])
+	assert.Equal(t, url+"/items?key=abc&skip=20&take=10", links["last"])
+
+	// Last page has no next link
+	response, err = http.Get(url + "/items?skip=20&take=10")
+	assert.Nil(t, err)
+	response.Body.Close()
+	links = parseLinks(response.Header.Get(services.LinkHeader))
+	assert.NotContains(t, links, "next")
+	assert.Equal(t, url+"/items?skip=10&take=10", links["prev"])
+
+	// Tokenized pages link to the next token
+	response, err = http.Get(url + "/cursor?take=10&token=10")
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, "", response.Header.Get(services.TotalCountHeader))
+	links = parseLinks(response.Header.Get(services.LinkHeader))
+	assert.Equal(t, url+"/cursor?take=10&token=20", links["next"])
+	assert.Equal(t, url+"/cursor?take=10", links["first"])
+
+	response, err = http.Get(url + "/cursor?take=10&token=20")
+	assert.Nil(t, err)
+	response.Body.Close()
+	links = parseLinks(response.Header.Get(services.LinkHeader))
+	assert.NotContains(t, links, "next")
+
+	// Routes without the option send no headers
+	response, err = http.Get(url + "/plain")
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, "", response.Header.Get(services.TotalCountHeader))
+	assert.Equal(t, "", response.Header.Get(services.LinkHeader))
+}
+
+func TestRestServicePagingHeaders(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", PagingRestServicePort,
+	))
+
+	pagedService := NewDummyRestService()
+	pagedService.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"base_route", "paged",
+		"options.paging_headers", true,
+	))
+	plainService := NewDummyRestService()
+	plainService.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"base_route", "api/paged",
+	))
+
+	references := cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services", "endpoint", "http", "default", "1.0"), endpoint,
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), tlogic.NewDummyController(),
+	)
+	pagedService.SetReferences(context.Background(), references)
+	plainService.SetReferences(context.Background(), references)
+
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d", PagingRestServicePort)
+
+	response, err := http.Get(url + "/paged/dummies?total=true")
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, "0", response.Header.Get(services.TotalCountHeader))
+
+	// The option doesn't leak to other services on the shared endpoint
+	response, err = http.Get(url + "/api/paged/dummies?total=true")
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, "", response.Header.Get(services.TotalCountHeader))
+}
@@ -0,0 +1,135 @@
+package test_services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tlogic "github.com/pip-services3-gox/pip-services3-rpc-gox/test/logic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemDetails(t *testing.T) {
+	err := cerr.NewNotFoundError("123", "NOT_FOUND_TEST", "Not found error").
+		WithDetails("id", "1")
+
+	problem := services.NewProblemDetailsFromError(err, "https://example.com/errors/")
+	assert.Equal(t, "https://example.com/errors/NotFound/NOT_FOUND_TEST", problem.Type)
+	assert.Equal(t, "Not Found", problem.Title)
+	assert.Equal(t, 404, problem.Status)
+	assert.Equal(t, "Not found error", problem.Detail)
+	assert.Equal(t, "123", problem.Instance)
+
+	data, jsonErr := json.Marshal(problem)
+	assert.Nil(t, jsonErr)
+	var values map[string]any
+	assert.Nil(t, json.Unmarshal(data, &values))
+	assert.Equal(t, "1", values["id"])
+	assert.Equal(t, "NOT_FOUND_TEST", values["code"])
+
+	var result services.ProblemDetails
+	assert.Nil(t, json.Unmarshal(data, &result))
+	desc := result.ToErrorDescription()
+	assert.Equal(t, cerr.NotFound, desc.Category)
+	assert.Equal(t, "NOT_FOUND_TEST", desc.Code)
+	assert.Equal(t, "Not found error", desc.Message)
+	assert.Equal(t, "123", desc.CorrelationId)
+	assert.Equal(t, "1", desc.Details["id"])
+
+	// Category is detected by status for foreign problems
+	result = services.ProblemDetails{Title: "Conflict", Status: 409}
+	desc = result.ToErrorDescription()
+	assert.Equal(t, cerr.Conflict, desc.Category)
+	assert.Equal(t, "Conflict", desc.Message)
+}
+
+func TestProblemDetailsErrorFormat(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", ProblemDetailsServicePort,
+		"options.error_format", "problem",
+	))
+
+	problemService := NewDummyRestService()
+	problemService.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"base_route", "api",
+	))
+	pipService := NewDummyRestService()
+	pipService.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"base_route", "legacy",
+		"options.error_format", "pip",
+	))
+
+	references := cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services", "endpoint", "http", "default", "1.0"), endpoint,
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), tlogic.NewDummyController(),
+	)
+	problemService.SetReferences(context.Background(), references)
+	pipService.SetReferences(context.Background(), references)
+
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d", ProblemDetailsServicePort)
+
+	// Errors are sent as problem details by the endpoint option
+	response, err := http.Get(url + "/api/dummies/check/error_propagation?correlation_id=test_problem")
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	assert.Equal(t, 404, response.StatusCode)
+	assert.Equal(t, "application/problem+json", response.Header.Get("Content-Type"))
+	var values map[string]any
+	assert.Nil(t, json.Unmarshal(body, &values))
+	assert.Equal(t, "urn:pip-services:error:NotFound/NOT_FOUND_TEST", values["type"])
+	assert.Equal(t, "Not Found", values["title"])
+	assert.Equal(t, 404.0, values["status"])
+	assert.Equal(t, "Not found error", values["detail"])
+	assert.Equal(t, "test_problem", values["instance"])
+
+	// Validation errors are sent as problem details as well
+	response, err = http.Post(url+"/api/dummies", "application/json", nil)
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, 400, response.StatusCode)
+	assert.Equal(t, "application/problem+json", response.Header.Get("Content-Type"))
+
+	// The service option overrides the endpoint format
+	response, err = http.Get(url + "/legacy/dummies/check/error_propagation")
+	assert.Nil(t, err)
+	body, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	assert.Equal(t, 404, response.StatusCode)
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	values = nil
+	assert.Nil(t, json.Unmarshal(body, &values))
+	assert.Equal(t, "NOT_FOUND_TEST", values["code"])
+	assert.Equal(t, "NotFound", values["category"])
+
+	// The service option applies to validation errors of the service routes only
+	response, err = http.Post(url+"/legacy/dummies", "application/json", nil)
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, 400, response.StatusCode)
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+
+	response, err = http.Get(url + "/api/dummies/check/error_propagation?legacy=true")
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, "application/problem+json", response.Header.Get("Content-Type"))
+}
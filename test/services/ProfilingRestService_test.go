@@ -0,0 +1,97 @@
+package test_services
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/auth"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfilingRestService(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", ProfilingRestServicePort,
+	))
+	references := cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services", "endpoint", "http", "default", "1.0"), endpoint,
+	)
+
+	newService := func(config ...any) *services.ProfilingRestService {
+		service := services.NewProfilingRestService()
+		service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(config...))
+		service.SetReferences(context.Background(), references)
+		return service
+	}
+	service := newService("options.enabled", true)
+	assert.True(t, service.IsEnabled())
+	newService("route", "restricted/pprof", "options.enabled", true, "options.allowed_ips", "10.0.0.0/8")
+	newService("route", "secured/pprof", "options.enabled", true).SetAuthorize((&auth.RoleAuthManager{}).Admin())
+	assert.False(t, newService("route", "disabled/pprof").IsEnabled())
+
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d", ProfilingRestServicePort)
+	get := func(route string) (int, string) {
+		response, err := http.Get(url + route)
+		assert.Nil(t, err)
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return response.StatusCode, string(body)
+	}
+
+	// Index of profiles, the route without trailing slash is redirected
+	status, body := get("/debug/pprof")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "goroutine")
+	assert.Contains(t, body, "heap")
+
+	status, body = get("/debug/pprof/goroutine?debug=1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "goroutine profile")
+
+	status, body = get("/debug/pprof/heap?debug=1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "heap profile")
+
+	status, _ = get("/debug/pprof/block")
+	assert.Equal(t, http.StatusOK, status)
+
+	status, _ = get("/debug/pprof/mutex")
+	assert.Equal(t, http.StatusOK, status)
+
+	status, _ = get("/debug/pprof/trace?seconds=0.1")
+	assert.Equal(t, http.StatusOK, status)
+
+	status, body = get("/debug/pprof/cmdline")
+	assert.Equal(t, http.StatusOK, status)
+	assert.NotEmpty(t, body)
+
+	status, _ = get("/debug/pprof/unknown")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	// Local clients are not in the allowlist
+	status, _ = get("/restricted/pprof/heap")
+	assert.Equal(t, http.StatusForbidden, status)
+
+	// Auth managers protect profiles
+	status, _ = get("/secured/pprof/heap")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// Disabled service registers no routes
+	status, _ = get("/disabled/pprof/heap")
+	assert.Equal(t, http.StatusNotFound, status)
+}
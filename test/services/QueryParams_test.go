@@ -0,0 +1,191 @@
+package test_services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cvalid "github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+type queryParamsRegistration struct {
+	*services.RestOperations
+	endpoint *services.HttpEndpoint
+}
+
+func (c *queryParamsRegistration) Register() {
+	c.endpoint.RegisterParamsRoute(
+		http.MethodGet, "/items",
+		cvalid.NewObjectSchema().
+			WithOptionalProperty("id", cvalid.NewArraySchema(cconv.String)).
+			WithOptionalProperty("code", cvalid.NewArraySchema(cvalid.NewSchema().
+				WithRule(cvalid.NewIncludedRule("a", "b")))).
+			WithOptionalProperty("take", cconv.String).
+			WithOptionalProperty("since", cconv.String),
+		nil,
+		func(res http.ResponseWriter, req *http.Request) {
+			take, takeOk := c.GetParamAsInt(req, "take")
+			since, sinceOk := c.GetParamAsTime(req, "since")
+			result := map[string]any{
+				"ids":   c.GetParamAsArray(req, "id"),
+				"codes": c.GetParamAsArray(req, "code"),
+				"take":  take,
+			}
+			if !takeOk {
+				result["take"] = nil
+			}
+			if sinceOk {
+				result["since"] = since.UTC().Format(time.RFC3339)
+			}
+			c.SendResult(res, req, result, nil)
+		},
+	)
+
+	itemSchema := cvalid.NewObjectSchema().
+		WithRequiredProperty("item_id", cconv.Long).
+		WithOptionalProperty("active", cconv.Boolean).
+		WithOptionalProperty("ratio", cconv.Double).
+		WithOptionalProperty("ids", cvalid.NewArraySchema(cconv.Integer)).
+		WithOptionalProperty("name", cconv.String)
+	getItem := func(res http.ResponseWriter, req *http.Request) {
+		types := make(map[string]string)
+		for k, v := range c.GetParams(req) {
+			types[k] = fmt.Sprintf("%T", v)
+		}
+		c.SendResult(res, req, map[string]any{
+			"params": c.GetParams(req),
+			"types":  types,
+		}, nil)
+	}
+	c.endpoint.RegisterParamsRoute(http.MethodGet, "/items/{item_id}", itemSchema, nil, getItem)
+	// Parameters of routes with a plain schema are converted as well
+	c.endpoint.RegisterRoute(http.MethodGet, "/plain/{item_id}", itemSchema.Schema, getItem)
+}
+
+func TestMultiValuedQueryParams(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", QueryParamsServicePort,
+	))
+	endpoint.Register(&queryParamsRegistration{
+		RestOperations: services.NewRestOperations(),
+		endpoint:       endpoint,
+	})
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d/items", QueryParamsServicePort)
+
+	// Repeated keys and comma lists are decoded into arrays
+	response, err := http.Get(url + "?id=1&id=2&id=3&code=a,b&take=10&since=2023-01-02T03:04:05Z")
+	assert.Nil(t, err)
+	resBody, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+
+	var result map[string]any
+	assert.Nil(t, json.Unmarshal(resBody, &result))
+	assert.Equal(t, []any{"1", "2", "3"}, result["ids"])
+	assert.Equal(t, []any{"a", "b"}, result["codes"])
+	assert.Equal(t, float64(10), result["take"])
+	assert.Equal(t, "2023-01-02T03:04:05Z", result["since"])
+
+	// Values of repeated keys are not split
+	response, err = http.Get(url + "?id=1,2&id=3")
+	assert.Nil(t, err)
+	resBody, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	result = nil
+	assert.Nil(t, json.Unmarshal(resBody, &result))
+	assert.Equal(t, []any{"1,2", "3"}, result["ids"])
+
+	// Every array item is validated
+	response, err = http.Get(url + "?code=a&code=c")
+	assert.Nil(t, err)
+	resBody, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 400, response.StatusCode)
+
+	var appErr map[string]any
+	assert.Nil(t, json.Unmarshal(resBody, &appErr))
+	assert.Equal(t, "INVALID_DATA", appErr["code"])
+
+	// Not array parameters take the first value
+	response, err = http.Get(url + "?take=5&take=abc")
+	assert.Nil(t, err)
+	resBody, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Nil(t, json.Unmarshal(resBody, &result))
+	assert.Equal(t, float64(5), result["take"])
+}
+
+func TestCoercedParams(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", CoercedParamsServicePort,
+	))
+	endpoint.Register(&queryParamsRegistration{
+		RestOperations: services.NewRestOperations(),
+		endpoint:       endpoint,
+	})
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	for _, route := range []string{"items", "plain"} {
+		url := fmt.Sprintf("http://localhost:%d/%s", CoercedParamsServicePort, route)
+
+		response, err := http.Get(url + "/123?active=true&ratio=0.5&ids=1,2&name=007")
+		assert.Nil(t, err)
+		resBody, _ := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		assert.Equal(t, 200, response.StatusCode)
+
+		var result struct {
+			Params map[string]any    `json:"params"`
+			Types  map[string]string `json:"types"`
+		}
+		assert.Nil(t, json.Unmarshal(resBody, &result))
+		assert.Equal(t, "int64", result.Types["item_id"])
+		assert.Equal(t, "bool", result.Types["active"])
+		assert.Equal(t, "float64", result.Types["ratio"])
+		assert.Equal(t, "[]interface {}", result.Types["ids"])
+		assert.Equal(t, "string", result.Types["name"])
+		assert.Equal(t, float64(123), result.Params["item_id"])
+		assert.Equal(t, []any{float64(1), float64(2)}, result.Params["ids"])
+		assert.Equal(t, "007", result.Params["name"])
+
+		// Values that can't be converted fail validation
+		response, err = http.Get(url + "/abc?active=maybe")
+		assert.Nil(t, err)
+		resBody, _ = ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		assert.Equal(t, 400, response.StatusCode)
+
+		var appErr cerr.ApplicationError
+		assert.Nil(t, json.Unmarshal(resBody, &appErr))
+		paths := make(map[string]string)
+		for _, fieldErr := range services.GetFieldValidationErrors(&appErr) {
+			paths[fieldErr.Path] = fieldErr.Code
+		}
+		assert.Equal(t, "TYPE_MISMATCH", paths["item_id"])
+		assert.Equal(t, "TYPE_MISMATCH", paths["active"])
+	}
+}
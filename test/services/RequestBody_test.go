@@ -0,0 +1,99 @@
+package test_services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cvalid "github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+type requestBodyRegistration struct {
+	*services.RestOperations
+	endpoint *services.HttpEndpoint
+}
+
+func (c *requestBodyRegistration) Register() {
+	c.endpoint.RegisterRoute(
+		http.MethodPost, "/echo",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("body", cvalid.NewObjectSchema().
+				WithRequiredProperty("name", cconv.String)).Schema,
+		func(res http.ResponseWriter, req *http.Request) {
+			var first, second map[string]any
+			if err := c.DecodeBody(req, &first); err != nil {
+				c.SendError(res, req, err)
+				return
+			}
+			if err := c.DecodeBody(req, &second); err != nil {
+				c.SendError(res, req, err)
+				return
+			}
+			value, err := services.GetRequestBodyValue(req)
+			if err != nil {
+				c.SendError(res, req, err)
+				return
+			}
+			raw, _ := ioutil.ReadAll(req.Body)
+			cached, _ := services.GetRequestBody(req)
+
+			c.SendResult(res, req, map[string]any{
+				"first":  first,
+				"second": second,
+				"value":  value,
+				"raw":    string(raw),
+				"cached": string(cached),
+			}, nil)
+		},
+	)
+}
+
+func TestRequestBodyCache(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", RequestBodyServicePort,
+	))
+	endpoint.Register(&requestBodyRegistration{
+		RestOperations: services.NewRestOperations(),
+		endpoint:       endpoint,
+	})
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d/echo", RequestBodyServicePort)
+
+	body := `{"name":"Item","count":5}`
+	response, err := http.Post(url, "application/json", bytes.NewBufferString(body))
+	assert.Nil(t, err)
+	resBody, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+
+	var result map[string]any
+	assert.Nil(t, json.Unmarshal(resBody, &result))
+	expected := map[string]any{"name": "Item", "count": float64(5)}
+	assert.Equal(t, expected, result["first"])
+	assert.Equal(t, expected, result["second"])
+	assert.Equal(t, expected, result["value"])
+	assert.Equal(t, body, result["raw"])
+	assert.Equal(t, body, result["cached"])
+
+	// Validation uses the same cached body
+	response, err = http.Post(url, "application/json", bytes.NewBufferString(`{"count":5}`))
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, 400, response.StatusCode)
+}
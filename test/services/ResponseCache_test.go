@@ -0,0 +1,152 @@
+package test_services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	ccache "github.com/pip-services3-gox/pip-services3-components-gox/cache"
+	ccount "github.com/pip-services3-gox/pip-services3-components-gox/count"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tdata "github.com/pip-services3-gox/pip-services3-rpc-gox/test/data"
+	tlogic "github.com/pip-services3-gox/pip-services3-rpc-gox/test/logic"
+	"github.com/stretchr/testify/assert"
+)
+
+func getCached(t *testing.T, url string, headers map[string]string) (*http.Response, []byte) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	assert.Nil(t, err)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	response, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	return response, body
+}
+
+func TestDummyRestServiceResponseCache(t *testing.T) {
+	counters := ccount.NewLogCounters()
+	service := NewDummyRestService()
+	service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", ResponseCacheServicePort,
+		"options.response_cache_timeout", 60000,
+	))
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), tlogic.NewDummyController(),
+		cref.NewDescriptor("pip-services", "counters", "log", "default", "1.0"), counters,
+	))
+	err := service.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer service.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d/cached/dummies", ResponseCacheServicePort)
+
+	response, body1 := getCached(t, url, nil)
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "MISS", response.Header.Get(services.ResponseCacheHeader))
+
+	response, body2 := getCached(t, url, nil)
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "HIT", response.Header.Get(services.ResponseCacheHeader))
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	assert.Equal(t, body1, body2)
+
+	// Query, headers and users are cached separately
+	response, _ = getCached(t, url+"?take=1", nil)
+	assert.Equal(t, "MISS", response.Header.Get(services.ResponseCacheHeader))
+	response, _ = getCached(t, url, map[string]string{"Authorization": "Bearer user1"})
+	assert.Equal(t, "MISS", response.Header.Get(services.ResponseCacheHeader))
+	response, _ = getCached(t, url, map[string]string{"Authorization": "Bearer user1"})
+	assert.Equal(t, "HIT", response.Header.Get(services.ResponseCacheHeader))
+
+	// No-cache requests refresh the response
+	response, _ = getCached(t, url, map[string]string{"Cache-Control": "no-cache"})
+	assert.Equal(t, "MISS", response.Header.Get(services.ResponseCacheHeader))
+
+	// Mutating routes invalidate cached responses
+	data, _ := json.Marshal(tdata.NewDummy("", "Cached Key", "Cached Content"))
+	response, err = http.Post(url, "application/json", bytes.NewBuffer(data))
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, 201, response.StatusCode)
+
+	response, body3 := getCached(t, url, nil)
+	assert.Equal(t, "MISS", response.Header.Get(services.ResponseCacheHeader))
+	assert.NotEqual(t, body1, body3)
+
+	hits, ok := counters.Get(context.Background(), ".dummies.cache_hits", ccount.Increment)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), hits.Count())
+	misses, ok := counters.Get(context.Background(), ".dummies.cache_misses", ccount.Increment)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), misses.Count())
+}
+
+func TestSharedResponseCache(t *testing.T) {
+	cache := ccache.NewMemoryCache[any]()
+	newService := func() *DummyRestService {
+		service := NewDummyRestService()
+		service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+			context.Background(),
+			cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), tlogic.NewDummyController(),
+			cref.NewDescriptor("pip-services", "response-cache", "memory", "default", "1.0"), cache,
+		))
+		return service
+	}
+	service1 := newService()
+	service2 := newService()
+
+	calls := 0
+	action := service1.CacheResponse("shared", 0, func(res http.ResponseWriter, req *http.Request) {
+		calls++
+		http.SetCookie(res, &http.Cookie{Name: "session", Value: "secret"})
+		res.Header().Set("correlation_id", service1.GetCorrelationId(req))
+		service1.SendResult(res, req, calls, nil)
+	})
+	get := func(correlationId string, userId string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/shared", nil)
+		req.Header.Set("correlation_id", correlationId)
+		if userId != "" {
+			req = req.WithContext(context.WithValue(req.Context(), services.PipAuthUserId, userId))
+		}
+		res := httptest.NewRecorder()
+		action(res, req)
+		return res
+	}
+
+	res := get("first", "")
+	assert.Equal(t, "MISS", res.Header().Get(services.ResponseCacheHeader))
+
+	// Cached responses have no headers of the first request
+	res = get("second", "")
+	assert.Equal(t, "HIT", res.Header().Get(services.ResponseCacheHeader))
+	assert.Equal(t, "1", res.Body.String())
+	assert.Empty(t, res.Header().Get("Set-Cookie"))
+	assert.Empty(t, res.Header().Get("correlation_id"))
+
+	// Authenticated users are cached separately
+	res = get("third", "user1")
+	assert.Equal(t, "MISS", res.Header().Get(services.ResponseCacheHeader))
+	res = get("fourth", "user1")
+	assert.Equal(t, "HIT", res.Header().Get(services.ResponseCacheHeader))
+
+	// Groups are invalidated by services that share the cache
+	assert.Nil(t, service2.ClearResponseCache(context.Background(), "", "shared"))
+	res = get("fifth", "")
+	assert.Equal(t, "MISS", res.Header().Get(services.ResponseCacheHeader))
+	assert.Equal(t, "3", res.Body.String())
+}
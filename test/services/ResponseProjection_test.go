@@ -0,0 +1,113 @@
+package test_services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tdata "github.com/pip-services3-gox/pip-services3-rpc-gox/test/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyProjection(t *testing.T) {
+	value := map[string]any{
+		"id":   "1",
+		"name": "Item",
+		"meta": map[string]any{"created": "2023-01-01", "author": "John"},
+		"tags": []any{
+			map[string]any{"key": "a", "value": 1},
+			map[string]any{"key": "b", "value": 2},
+		},
+	}
+
+	result, err := services.ApplyProjection(value, cdata.ParseProjectionParams("id,meta.created,tags(key)"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{
+		"id":   "1",
+		"meta": map[string]any{"created": "2023-01-01"},
+		"tags": []any{
+			map[string]any{"key": "a"},
+			map[string]any{"key": "b"},
+		},
+	}, result)
+
+	// Whole field wins over its nested fields
+	result, err = services.ApplyProjection(value, cdata.ParseProjectionParams("meta,meta.author"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"meta": value["meta"]}, result)
+
+	// Data pages are projected by items
+	page := cdata.NewDataPage([]tdata.Dummy{
+		*tdata.NewDummy("1", "Key 1", "Content 1"),
+		*tdata.NewDummy("2", "Key 2", "Content 2"),
+	}, 2)
+	result, err = services.ApplyProjection(page, cdata.ParseProjectionParams("id"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{
+		"total": json.Number("2"),
+		"data":  []any{map[string]any{"id": "1"}, map[string]any{"id": "2"}},
+	}, result)
+
+	// Large integers keep their precision
+	result, err = services.ApplyProjection(map[string]any{"id": int64(9007199254740993), "name": "Item"},
+		cdata.ParseProjectionParams("id"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"id": json.Number("9007199254740993")}, result)
+	data, _ := json.Marshal(result)
+	assert.Equal(t, `{"id":9007199254740993}`, string(data))
+
+	// Empty projection keeps the value
+	result, err = services.ApplyProjection(value, cdata.NewEmptyProjectionParams())
+	assert.Nil(t, err)
+	assert.Equal(t, value, result)
+}
+
+func TestDummyRestServiceProjection(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d", DummyOpenAPIFileRestServicePort)
+
+	body, _ := json.Marshal(tdata.NewDummy("", "Projected Key", "Projected Content"))
+	response, err := http.Post(url+"/dummies", "application/json", bytes.NewBuffer(body))
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, 201, response.StatusCode)
+
+	response, err = http.Get(url + "/projected/dummies?fields=id,key")
+	assert.Nil(t, err)
+	resBody, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+
+	var page map[string]any
+	assert.Nil(t, json.Unmarshal(resBody, &page))
+	items, ok := page["data"].([]any)
+	assert.True(t, ok)
+	assert.NotEmpty(t, items)
+	for _, item := range items {
+		fields := item.(map[string]any)
+		assert.Contains(t, fields, "id")
+		assert.Contains(t, fields, "key")
+		assert.NotContains(t, fields, "content")
+	}
+
+	// Routes without projection send whole results
+	response, err = http.Get(url + "/dummies/check/query_params?fields=id")
+	assert.Nil(t, err)
+	resBody, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Nil(t, json.Unmarshal(resBody, &page))
+	assert.Contains(t, page, "filter")
+}
+
+func TestSendResultWithoutRequest(t *testing.T) {
+	// Results can be sent without a request, i.e. from business logic components
+	res := httptest.NewRecorder()
+	services.HttpResponseSender.SendResult(res, nil, tdata.NewDummy("1", "Key", "Content"), nil)
+	assert.Equal(t, 200, res.Code)
+	assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+}
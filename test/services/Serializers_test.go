@@ -0,0 +1,202 @@
+package test_services
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tdata "github.com/pip-services3-gox/pip-services3-rpc-gox/test/data"
+	"github.com/stretchr/testify/assert"
+)
+
+type cborTestBase struct {
+	Id string `json:"id"`
+}
+
+type cborTestObject struct {
+	cborTestBase
+	Name     string            `json:"name"`
+	Count    int               `json:"count"`
+	Delta    int64             `json:"delta"`
+	Ratio    float64           `json:"ratio"`
+	Enabled  bool              `json:"enabled"`
+	Tags     []string          `json:"tags"`
+	Data     []byte            `json:"data"`
+	Props    map[string]any    `json:"props"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Time     time.Time         `json:"time"`
+	Parent   *tdata.Dummy      `json:"parent"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+func TestCborSerializer(t *testing.T) {
+	serializer := services.NewCborSerializer()
+
+	value := cborTestObject{
+		cborTestBase: cborTestBase{Id: "1"},
+		Name:         "Test",
+		Count:        1000000,
+		Delta:        -500,
+		Ratio:        0.25,
+		Enabled:      true,
+		Tags:         []string{"a", "b"},
+		Data:         []byte{1, 2, 3},
+		Props:        map[string]any{"key": "value", "number": int64(-1)},
+		Time:         time.Date(2022, 5, 10, 12, 30, 0, 0, time.UTC),
+		Parent:       tdata.NewDummy("2", "Key", "Content"),
+		Ignored:      "ignored",
+		internal:     "internal",
+	}
+
+	data, err := serializer.Serialize(value)
+	assert.Nil(t, err)
+
+	var result cborTestObject
+	err = serializer.Deserialize(data, &result)
+	assert.Nil(t, err)
+
+	value.Ignored = ""
+	value.internal = ""
+	assert.Equal(t, value, result)
+
+	// Generic values
+	var generic map[string]any
+	err = serializer.Deserialize(data, &generic)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", generic["id"])
+	assert.Equal(t, int64(1000000), generic["count"])
+	assert.Equal(t, "2022-05-10T12:30:00Z", generic["time"])
+	assert.NotContains(t, generic, "labels")
+	assert.NotContains(t, generic, "Ignored")
+
+	// Types with custom JSON marshaling
+	page := cdata.NewDataPage[tdata.Dummy]([]tdata.Dummy{*tdata.NewDummy("1", "Key", "Content")}, 1)
+	data, err = serializer.Serialize(page)
+	assert.Nil(t, err)
+	var pageResult cdata.DataPage[tdata.Dummy]
+	err = serializer.Deserialize(data, &pageResult)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, pageResult.Total)
+	assert.Equal(t, page.Data, pageResult.Data)
+}
+
+func TestCborSerializerDecoding(t *testing.T) {
+	serializer := services.NewCborSerializer()
+
+	// Examples from RFC 8949 Appendix A
+	examples := map[string]any{
+		"17":                         int64(23),
+		"1903e8":                     int64(1000),
+		"3903e7":                     int64(-1000),
+		"f93c00":                     1.0,
+		"f9c400":                     -4.0,
+		"fa47c35000":                 100000.0,
+		"fb3ff199999999999a":         1.1,
+		"f5":                         true,
+		"f6":                         nil,
+		"6449455446":                 "IETF",
+		"7f657374726561646d696e67ff": "streaming",
+		"9f018202039f0405ffff":       []any{int64(1), []any{int64(2), int64(3)}, []any{int64(4), int64(5)}},
+		"bf61610161629f0203ffff":     map[string]any{"a": int64(1), "b": []any{int64(2), int64(3)}},
+		"c074323031332d30332d32315432303a30343a30305a": "2013-03-21T20:04:00Z",
+	}
+	for input, expected := range examples {
+		data, _ := hex.DecodeString(input)
+		var result any
+		err := serializer.Deserialize(data, &result)
+		assert.Nil(t, err, input)
+		assert.Equal(t, expected, result, input)
+	}
+
+	var number float64
+	data, _ := hex.DecodeString("f97c00")
+	assert.Nil(t, serializer.Deserialize(data, &number))
+	assert.Equal(t, math.Inf(1), number)
+
+	// Invalid data
+	var small int8
+	data, _ = hex.DecodeString("1903e8")
+	assert.NotNil(t, serializer.Deserialize(data, &small))
+
+	var dummy tdata.Dummy
+	data, _ = hex.DecodeString("9bffffffffffffffff")
+	assert.NotNil(t, serializer.Deserialize(data, &dummy))
+
+	data, _ = hex.DecodeString("a1626964")
+	assert.NotNil(t, serializer.Deserialize(data, &dummy))
+
+	// Fields of unexported nil embedded pointers are skipped
+	var embedded struct {
+		*cborTestBase
+		Name string `json:"name"`
+	}
+	data, _ = serializer.Serialize(map[string]any{"id": "1", "name": "Test"})
+	assert.Nil(t, serializer.Deserialize(data, &embedded))
+	assert.Nil(t, embedded.cborTestBase)
+	assert.Equal(t, "Test", embedded.Name)
+}
+
+func TestSerializerNegotiation(t *testing.T) {
+	registry := services.NewSerializerRegistry()
+
+	assert.Equal(t, services.JsonContentType, registry.Negotiate("").ContentType())
+	assert.Equal(t, services.JsonContentType, registry.Negotiate("*/*").ContentType())
+	assert.Equal(t, services.JsonContentType, registry.Negotiate("text/html").ContentType())
+	assert.Equal(t, services.CborContentType, registry.Negotiate("application/cbor").ContentType())
+	assert.Equal(t, services.CborContentType,
+		registry.Negotiate("application/json;q=0.5, application/cbor").ContentType())
+	assert.Equal(t, services.JsonContentType,
+		registry.Negotiate("application/cbor;q=0.1, application/*;q=0.9").ContentType())
+
+	serializer, ok := registry.Get("application/merge-patch+json; charset=utf-8")
+	assert.True(t, ok)
+	assert.Equal(t, services.JsonContentType, serializer.ContentType())
+
+	_, ok = registry.Get("text/plain")
+	assert.False(t, ok)
+}
+
+func TestDummyRestServiceCbor(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d", DummyOpenAPIFileRestServicePort)
+	serializer := services.NewCborSerializer()
+
+	body, err := serializer.Serialize(tdata.NewDummy("", "Key", "Content"))
+	assert.Nil(t, err)
+
+	req, reqErr := http.NewRequest(http.MethodPost, url+"/dummies", bytes.NewBuffer(body))
+	assert.Nil(t, reqErr)
+	req.Header.Set("Content-Type", services.CborContentType)
+	req.Header.Set("Accept", services.CborContentType)
+	postResponse, postErr := http.DefaultClient.Do(req)
+	assert.Nil(t, postErr)
+	resBody, bodyErr := ioutil.ReadAll(postResponse.Body)
+	assert.Nil(t, bodyErr)
+	postResponse.Body.Close()
+
+	assert.Equal(t, 201, postResponse.StatusCode)
+	assert.Equal(t, services.CborContentType, postResponse.Header.Get("Content-Type"))
+
+	var dummy tdata.Dummy
+	err = serializer.Deserialize(resBody, &dummy)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", dummy.Id)
+	assert.Equal(t, "Key", dummy.Key)
+
+	// Invalid body is rejected by schema validation
+	body, _ = serializer.Serialize(map[string]any{"id": 1})
+	req, _ = http.NewRequest(http.MethodPost, url+"/dummies", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", services.CborContentType)
+	postResponse, postErr = http.DefaultClient.Do(req)
+	assert.Nil(t, postErr)
+	postResponse.Body.Close()
+	assert.Equal(t, 400, postResponse.StatusCode)
+	assert.Equal(t, services.JsonContentType, postResponse.Header.Get("Content-Type"))
+}
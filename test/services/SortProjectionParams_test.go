@@ -0,0 +1,35 @@
+package test_services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyRestServiceSortAndProjectionParams(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/dummies/check/query_params", DummyOpenAPIFileRestServicePort)
+
+	response, err := http.Get(url + "?key=Key+1&skip=10&take=5&sort=name,-created&sort=%2Bkey&fields=id,content(text,tags),meta.created")
+	assert.Nil(t, err)
+	resBody, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+
+	var result map[string]any
+	assert.Nil(t, json.Unmarshal(resBody, &result))
+	assert.Equal(t, map[string]any{"key": "Key 1"}, result["filter"])
+	assert.Equal(t, []any{
+		map[string]any{"name": "name", "ascending": true},
+		map[string]any{"name": "created", "ascending": false},
+		map[string]any{"name": "key", "ascending": true},
+	}, result["sort"])
+	assert.Equal(t, []any{"id", "content.text", "content.tags", "meta.created"}, result["fields"])
+
+	paging := result["paging"].(map[string]any)
+	assert.Equal(t, float64(10), paging["skip"])
+	assert.Equal(t, float64(5), paging["take"])
+}
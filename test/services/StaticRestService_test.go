@@ -0,0 +1,104 @@
+package test_services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRestService(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(path.Join(dir, "index.html"), []byte("<html>app</html>"), 0644))
+	assert.Nil(t, os.WriteFile(path.Join(dir, "app.js"), []byte("console.log('app')"), 0644))
+	var gzBuf bytes.Buffer
+	gzWriter := gzip.NewWriter(&gzBuf)
+	_, _ = gzWriter.Write([]byte("console.log('app')"))
+	_ = gzWriter.Close()
+	assert.Nil(t, os.WriteFile(path.Join(dir, "app.js.gz"), gzBuf.Bytes(), 0644))
+	assert.Nil(t, os.WriteFile(path.Join(path.Dir(dir), "secret.txt"), []byte("secret"), 0644))
+	defer os.Remove(path.Join(path.Dir(dir), "secret.txt"))
+
+	service := services.NewStaticRestService()
+	service.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", StaticRestServicePort,
+		"route", "admin",
+		"path", dir,
+		"options.spa", true,
+		"options.max_age", 3600,
+		"options.precompressed", true,
+	))
+	err := service.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer service.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d", StaticRestServicePort)
+
+	// Serve index file
+	getResponse, getErr := http.Get(url + "/admin/")
+	assert.Nil(t, getErr)
+	resBody, _ := ioutil.ReadAll(getResponse.Body)
+	getResponse.Body.Close()
+	assert.Equal(t, 200, getResponse.StatusCode)
+	assert.Equal(t, "<html>app</html>", string(resBody))
+	assert.Contains(t, getResponse.Header.Get("Content-Type"), "text/html")
+	assert.Equal(t, "no-cache", getResponse.Header.Get("Cache-Control"))
+
+	// Fallback to index file for client-side routes
+	getResponse, getErr = http.Get(url + "/admin/users/123")
+	assert.Nil(t, getErr)
+	resBody, _ = ioutil.ReadAll(getResponse.Body)
+	getResponse.Body.Close()
+	assert.Equal(t, 200, getResponse.StatusCode)
+	assert.Equal(t, "<html>app</html>", string(resBody))
+
+	// Serve cached files
+	getResponse, getErr = http.Get(url + "/admin/app.js")
+	assert.Nil(t, getErr)
+	resBody, _ = ioutil.ReadAll(getResponse.Body)
+	getResponse.Body.Close()
+	assert.Equal(t, 200, getResponse.StatusCode)
+	assert.Equal(t, "console.log('app')", string(resBody))
+	assert.Contains(t, getResponse.Header.Get("Content-Type"), "javascript")
+	assert.Equal(t, "public, max-age=3600", getResponse.Header.Get("Cache-Control"))
+	assert.Empty(t, getResponse.Header.Get("Pragma"))
+
+	// Serve precompressed files
+	req, _ := http.NewRequest(http.MethodGet, url+"/admin/app.js", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	getResponse, getErr = http.DefaultTransport.RoundTrip(req)
+	assert.Nil(t, getErr)
+	resBody, _ = ioutil.ReadAll(getResponse.Body)
+	getResponse.Body.Close()
+	assert.Equal(t, "gzip", getResponse.Header.Get("Content-Encoding"))
+	assert.Equal(t, gzBuf.Bytes(), resBody)
+
+	// Missing files with extensions are not found
+	getResponse, getErr = http.Get(url + "/admin/missing.css")
+	assert.Nil(t, getErr)
+	getResponse.Body.Close()
+	assert.Equal(t, 404, getResponse.StatusCode)
+
+	// Prevent path traversal
+	req, _ = http.NewRequest(http.MethodGet, url+"/admin/", nil)
+	req.URL.Path = "/admin/../secret.txt"
+	req.URL.RawPath = "/admin/%2e%2e/secret.txt"
+	getResponse, getErr = http.DefaultTransport.RoundTrip(req)
+	assert.Nil(t, getErr)
+	resBody, _ = ioutil.ReadAll(getResponse.Body)
+	getResponse.Body.Close()
+	assert.NotEqual(t, "secret", string(resBody))
+}
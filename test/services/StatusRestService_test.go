@@ -1,10 +1,13 @@
 package test_services
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,4 +18,38 @@ func TestStatusRestService(t *testing.T) {
 	getRes, getErr := http.Get(url + "/status")
 	assert.Nil(t, getErr)
 	assert.NotNil(t, getRes)
+
+	body, _ := ioutil.ReadAll(getRes.Body)
+	getRes.Body.Close()
+	assert.Equal(t, 200, getRes.StatusCode)
+
+	var status services.StatusReport
+	assert.Nil(t, json.Unmarshal(body, &status))
+	assert.Equal(t, "Test", status.Name)
+	assert.Equal(t, "This is a test container", status.Description)
+	assert.GreaterOrEqual(t, status.Uptime, int64(0))
+	assert.Contains(t, status.Components, "pip-services:status-service:http:default:1.0")
+
+	// Components are reported with their types and open states
+	for _, component := range status.ComponentStates {
+		if component.Locator == "pip-services:status-service:http:default:1.0" {
+			assert.Equal(t, "*services.StatusRestService", component.Type)
+			if assert.NotNil(t, component.Open) {
+				assert.True(t, *component.Open)
+			}
+		}
+	}
+
+	// The local endpoint is reported with its address and routes
+	if assert.Len(t, status.Endpoints, 1) {
+		assert.Equal(t, fmt.Sprintf("localhost:%d", StatusRestServicePort), status.Endpoints[0].Address)
+		assert.True(t, status.Endpoints[0].Open)
+		assert.Equal(t, 1, status.Endpoints[0].Routes)
+	}
+
+	if assert.NotNil(t, status.Runtime) {
+		assert.NotEmpty(t, status.Runtime.GoVersion)
+		assert.Greater(t, status.Runtime.Goroutines, 0)
+		assert.Greater(t, status.Runtime.HeapAlloc, uint64(0))
+	}
 }
@@ -0,0 +1,144 @@
+package test_services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tlogic "github.com/pip-services3-gox/pip-services3-rpc-gox/test/logic"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const swaggerTestOpenApiContent = `
+openapi: '3.0.2'
+info:
+  title: 'Dummies'
+  version: '1'
+tags:
+  - name: dummies
+paths:
+  /api/dummies:
+    get:
+      tags:
+        - dummies
+      responses:
+        200:
+          description: 'Successful response'
+components:
+  schemas:
+    Dummy:
+      type: object
+`
+
+func TestSwaggerRestService(t *testing.T) {
+	endpoint := services.NewHttpEndpoint()
+	endpoint.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"connection.protocol", "http",
+		"connection.host", "localhost",
+		"connection.port", SwaggerRestServicePort,
+	))
+
+	swaggerService := services.NewSwaggerRestService()
+	swaggerService.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"options.title", "Dummies API",
+	))
+
+	commandableService := NewDummyCommandableHttpService()
+	commandableService.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"swagger.enable", true,
+	))
+
+	restService := NewDummyRestService()
+	restService.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		"base_route", "api",
+		"swagger.enable", true,
+		"openapi_content", swaggerTestOpenApiContent,
+	))
+
+	references := cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), tlogic.NewDummyController(),
+		cref.NewDescriptor("pip-services", "endpoint", "http", "default", "1.0"), endpoint,
+		cref.NewDescriptor("pip-services", "swagger-service", "http", "default", "1.0"), swaggerService,
+		cref.NewDescriptor("pip-services-dummies", "service", "http", "default", "1.0"), commandableService,
+		cref.NewDescriptor("pip-services-dummies", "service", "rest", "default", "1.0"), restService,
+	)
+	swaggerService.SetReferences(context.Background(), references)
+	commandableService.SetReferences(context.Background(), references)
+	restService.SetReferences(context.Background(), references)
+
+	err := endpoint.Open(context.Background(), "")
+	assert.Nil(t, err)
+	defer endpoint.Close(context.Background(), "")
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d/swagger", SwaggerRestServicePort)
+	get := func(route string) (*http.Response, []byte) {
+		response, err := http.Get(url + route)
+		assert.Nil(t, err)
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return response, body
+	}
+
+	// Index of registered documents
+	response, body := get("/specs")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var specs []*services.SwaggerSpec
+	assert.Nil(t, json.Unmarshal(body, &specs))
+	assert.Equal(t, []*services.SwaggerSpec{
+		{Name: "api", Url: "/api/swagger"},
+		{Name: "dummies", Url: "/dummies/swagger"},
+	}, specs)
+
+	// UI page, the route without trailing slash is redirected
+	response, body = get("")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Contains(t, response.Header.Get("Content-Type"), "text/html")
+	assert.Contains(t, string(body), "swagger-ui-bundle.js")
+	assert.Contains(t, string(body), `{"name":"all","url":"/swagger/spec"}`)
+	assert.Contains(t, string(body), `{"name":"dummies","url":"/dummies/swagger"}`)
+
+	// Embedded assets
+	response, body = get("/swagger-ui-bundle.js")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Contains(t, string(body), "SwaggerUIBundle")
+
+	response, _ = get("/swagger-ui.css")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Contains(t, response.Header.Get("Content-Type"), "text/css")
+
+	// Files that are not assets are not served
+	response, _ = get("/README.md")
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	response, _ = get("/LICENSE")
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	// Merged document
+	response, body = get("/spec")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var spec struct {
+		Info struct {
+			Title string `yaml:"title"`
+		} `yaml:"info"`
+		Paths      map[string]any `yaml:"paths"`
+		Components struct {
+			Schemas map[string]any `yaml:"schemas"`
+		} `yaml:"components"`
+		Tags []map[string]any `yaml:"tags"`
+	}
+	assert.Nil(t, yaml.Unmarshal(body, &spec))
+	assert.Equal(t, "Dummies API", spec.Info.Title)
+	assert.Contains(t, spec.Paths, "/api/dummies")
+	assert.Contains(t, spec.Paths, "/dummies/create_dummy")
+	assert.Contains(t, spec.Components.Schemas, "Dummy")
+	assert.Len(t, spec.Tags, 1)
+}
@@ -0,0 +1,37 @@
+package test_services
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceContext(t *testing.T) {
+	traceContext, ok := services.ParseTraceContext("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "congo=t61rcWkgMzE")
+	assert.True(t, ok)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", traceContext.TraceId)
+	assert.Equal(t, "b7ad6b7169203331", traceContext.ParentId)
+	assert.Len(t, traceContext.SpanId, 16)
+	assert.Equal(t, "01", traceContext.Flags)
+	assert.Equal(t, "congo=t61rcWkgMzE", traceContext.State)
+
+	child := traceContext.NewChild()
+	assert.Equal(t, traceContext.TraceId, child.TraceId)
+	assert.Equal(t, traceContext.SpanId, child.ParentId)
+
+	header := http.Header{}
+	child.Inject(header)
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-"+child.SpanId+"-01", header.Get("traceparent"))
+	assert.Equal(t, "congo=t61rcWkgMzE", header.Get("tracestate"))
+
+	_, ok = services.ParseTraceContext("00-00000000000000000000000000000000-b7ad6b7169203331-01", "")
+	assert.False(t, ok)
+	_, ok = services.ParseTraceContext("ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "")
+	assert.False(t, ok)
+	_, ok = services.ParseTraceContext("00-0af7651916cd43dd8448eb211c80319c-B7AD6B7169203331-01", "")
+	assert.False(t, ok)
+	_, ok = services.ParseTraceContext("", "")
+	assert.False(t, ok)
+}
@@ -0,0 +1,106 @@
+package test_services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	ccache "github.com/pip-services3-gox/pip-services3-components-gox/cache"
+	"github.com/pip-services3-gox/pip-services3-rpc-gox/services"
+	tlogic "github.com/pip-services3-gox/pip-services3-rpc-gox/test/logic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedCache(t *testing.T) {
+	ctx := context.Background()
+	anyCache := ccache.NewMemoryCache[any]()
+	cache := services.NewTypedCache[*services.CachedResponse](anyCache)
+
+	value, err := cache.Retrieve(ctx, "", "key1")
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+
+	response := &services.CachedResponse{
+		Status: 200,
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+		Body:   []byte(`{"id":"1"}`),
+	}
+	_, err = cache.Store(ctx, "", "key1", response, 60000)
+	assert.Nil(t, err)
+	assert.True(t, cache.Contains(ctx, "", "key1"))
+
+	value, err = cache.Retrieve(ctx, "", "key1")
+	assert.Nil(t, err)
+	assert.Equal(t, response, value)
+
+	// Values decoded by distributed caches are converted
+	_, err = anyCache.Store(ctx, "", "key2", map[string]any{
+		"status": 200,
+		"header": map[string]any{"Content-Type": []any{"application/json"}},
+		"body":   "eyJpZCI6IjEifQ==",
+	}, 60000)
+	assert.Nil(t, err)
+	value, err = cache.Retrieve(ctx, "", "key2")
+	assert.Nil(t, err)
+	assert.Equal(t, response, value)
+
+	assert.Nil(t, cache.Remove(ctx, "", "key1"))
+	assert.False(t, cache.Contains(ctx, "", "key1"))
+}
+
+func TestRestServiceCacheReferences(t *testing.T) {
+	controller := tlogic.NewDummyController()
+
+	// Caches created by factories are adapted to cached responses
+	service := NewDummyRestService()
+	defaultStore := service.IdempotencyStore
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), controller,
+		cref.NewDescriptor("pip-services", "response-cache", "memory", "default", "1.0"), ccache.NewMemoryCache[any](),
+	))
+	assert.IsType(t, &services.TypedCache[*services.CachedResponse]{}, service.ResponseCache)
+	assert.Same(t, defaultStore, service.IdempotencyStore)
+
+	// Generic caches are not used for cached responses or idempotency keys
+	service = NewDummyRestService()
+	defaultCache := service.ResponseCache
+	defaultStore = service.IdempotencyStore
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), controller,
+		cref.NewDescriptor("pip-services", "cache", "memory", "default", "1.0"), ccache.NewMemoryCache[any](),
+	))
+	assert.Equal(t, defaultCache, service.ResponseCache)
+	assert.Same(t, defaultStore, service.IdempotencyStore)
+
+	// Caches of other types are ignored
+	service = NewDummyRestService()
+	defaultCache = service.ResponseCache
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), controller,
+		cref.NewDescriptor("pip-services", "response-cache", "memory", "default", "1.0"), ccache.NewMemoryCache[string](),
+	))
+	assert.Equal(t, defaultCache, service.ResponseCache)
+
+	// Idempotency stores are set by references
+	store := services.NewMemoryIdempotencyStore()
+	service = NewDummyRestService()
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), controller,
+		cref.NewDescriptor("pip-services", "idempotency-store", "memory", "default", "1.0"), store,
+	))
+	assert.Same(t, store, service.IdempotencyStore)
+
+	// Caches created by factories are adapted to idempotency stores
+	service = NewDummyRestService()
+	service.SetReferences(context.Background(), cref.NewReferencesFromTuples(
+		context.Background(),
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), controller,
+		cref.NewDescriptor("pip-services", "idempotency-store", "memory", "default", "1.0"), ccache.NewMemoryCache[any](),
+	))
+	assert.IsType(t, &services.CacheIdempotencyStore{}, service.IdempotencyStore)
+}
@@ -0,0 +1,100 @@
+package test_services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	tdata "github.com/pip-services3-gox/pip-services3-rpc-gox/test/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyRestServiceTypedRoutes(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/typed", DummyOpenAPIFileRestServicePort)
+
+	// Create a dummy from the whole body
+	body, _ := json.Marshal(tdata.NewDummy("", "Key 1", "Content 1"))
+	response, err := http.Post(url+"/dummies", "application/json", bytes.NewBuffer(body))
+	assert.Nil(t, err)
+	resBody, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 201, response.StatusCode)
+
+	var dummy tdata.Dummy
+	assert.Nil(t, json.Unmarshal(resBody, &dummy))
+	assert.NotEqual(t, "", dummy.Id)
+	assert.Equal(t, "Key 1", dummy.Key)
+
+	// Get the dummy by path parameter
+	response, err = http.Get(url + "/dummies/" + dummy.Id)
+	assert.Nil(t, err)
+	resBody, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+
+	var received tdata.Dummy
+	assert.Nil(t, json.Unmarshal(resBody, &received))
+	assert.Equal(t, dummy, received)
+
+	// Update binds the path parameter and the body field
+	body, _ = json.Marshal(tdata.NewDummy("", "Key 1", "Updated Content 1"))
+	req, _ := http.NewRequest(http.MethodPut, url+"/dummies/"+dummy.Id, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	response, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	resBody, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Nil(t, json.Unmarshal(resBody, &received))
+	assert.Equal(t, dummy.Id, received.Id)
+	assert.Equal(t, "Updated Content 1", received.Content)
+
+	// Invalid body is rejected by schema validation
+	body, _ = json.Marshal(map[string]any{"id": 1})
+	response, err = http.Post(url+"/dummies", "application/json", bytes.NewBuffer(body))
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, 400, response.StatusCode)
+
+	// Delete requires authorization
+	req, _ = http.NewRequest(http.MethodDelete, url+"/dummies/"+dummy.Id, nil)
+	response, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, 401, response.StatusCode)
+
+	req, _ = http.NewRequest(http.MethodDelete, url+"/dummies/"+dummy.Id, nil)
+	req.Header.Set("access_token", "dummy_token")
+	response, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+}
+
+func TestDummyRestServiceTypedQueryParams(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/typed/echo", DummyOpenAPIFileRestServicePort)
+
+	response, err := http.Get(url + "?skip=10&id=1&id=2&flag=true&since=2023-01-02T03:04:05Z")
+	assert.Nil(t, err)
+	resBody, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+
+	var result map[string]any
+	assert.Nil(t, json.Unmarshal(resBody, &result))
+	assert.Equal(t, float64(10), result["skip"])
+	assert.Equal(t, []any{"1", "2"}, result["ids"])
+	assert.Equal(t, true, result["flag"])
+	assert.Equal(t, "2023-01-02T03:04:05Z", result["since"])
+
+	// Values that can't be converted are rejected
+	response, err = http.Get(url + "?skip=abc")
+	assert.Nil(t, err)
+	resBody, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, 400, response.StatusCode)
+	assert.Contains(t, string(resBody), "BIND_FAILED")
+}